@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package authorization authorizes the identities which call the functions of the OpsSC chaincodes
+// (chaincode-ops and channel-ops) on behalf of each organization.
+//
+// Without any rules, every identity from a member MSP can call the functions.
+// Each organization sets the rules for its own identities by its admin,
+// and a rule restricts a function to the identities with any of the given MSP roles (OUs) or certificate attributes.
+// The rules are stored as a state in the ledger of each chaincode.
+package authorization
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ConfigObjectType is the object type of the authorization configs.
+const ConfigObjectType = "authorizationConfig"
+
+// AdminRole is the MSP role which can set the authorization rules of the organization.
+const AdminRole = "admin"
+
+var (
+	// ErrUnauthorized is returned when the caller is not authorized by the authorization rules of its organization.
+	ErrUnauthorized = fmt.Errorf("the identity is not authorized")
+)
+
+// Rule restricts the identities which can call the function.
+type Rule struct {
+	Function   string   `json:"function"`                                  // the name of the function (e.g., Vote)
+	Roles      []string `json:"roles,omitempty" metadata:",optional"`      // the MSP roles allowed to call the function (e.g., admin)
+	Attributes []string `json:"attributes,omitempty" metadata:",optional"` // the certificate attributes allowed to call the function (e.g., opssc.role=voter)
+}
+
+// Config describes the authorization rules of an organization that is stored as a state in the ledger.
+type Config struct {
+	ObjectType  string `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	MSPID       string `json:"mspID"`
+	Rules       []Rule `json:"rules"`
+	UpdatedByID string `json:"updatedByID"` // the identity ID of the admin which set the rules
+	Time        string `json:"time"`
+}
+
+// Authorizer authorizes the callers of the functions which can be restricted by the rules.
+type Authorizer struct {
+	Functions []string // the functions which can be restricted by the rules
+}
+
+// SetRules sets the rules for the identities of the given organization, which should be the caller's one.
+// This replaces the existing rules of the organization, and removes them if no rule is given (and then returns nil).
+// The caller should be the admin of the organization.
+func (a *Authorizer) SetRules(ctx contractapi.TransactionContextInterface, mspID string, rules []Rule, txTimestamp string) (*Config, error) {
+	if err := a.validateRules(rules); err != nil {
+		return nil, err
+	}
+
+	isAdmin, err := hasRole(ctx.GetClientIdentity(), AdminRole)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, fmt.Errorf("%w to set the authorization rules: it should have the role %s", ErrUnauthorized, AdminRole)
+	}
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ConfigObjectType, []string{mspID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for authorization config: %v", err)
+	}
+	if len(rules) == 0 {
+		if err := ctx.GetStub().DelState(compositeKey); err != nil {
+			return nil, fmt.Errorf("error happened deleting the authorization config: %v", err)
+		}
+		return nil, nil
+	}
+
+	identityID, err := IdentityID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{
+		ObjectType:  ConfigObjectType,
+		MSPID:       mspID,
+		Rules:       rules,
+		UpdatedByID: identityID,
+		Time:        txTimestamp,
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("error happened marshalling the authorization config: %v", err)
+	}
+	if err := ctx.GetStub().PutState(compositeKey, configJSON); err != nil {
+		return nil, fmt.Errorf("error happened persisting the authorization config on the ledger: %v", err)
+	}
+	return config, nil
+}
+
+// GetConfig returns the authorization config stored for the given organization (nil if no rule is set).
+func GetConfig(ctx contractapi.TransactionContextInterface, mspID string) (*Config, error) {
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ConfigObjectType, []string{mspID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for authorization config: %v", err)
+	}
+	configJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading authorization config of %s: %v", mspID, err)
+	}
+	if configJSON == nil {
+		return nil, nil
+	}
+
+	var config Config
+	if err = json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling an authorization config JSON representation to struct: %v", err)
+	}
+	return &config, nil
+}
+
+// Authorize checks that the caller from the given organization is allowed to call the function by the rules of the organization.
+func (a *Authorizer) Authorize(ctx contractapi.TransactionContextInterface, mspID string, function string) error {
+	config, err := GetConfig(ctx, mspID)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return nil
+	}
+
+	for _, rule := range config.Rules {
+		if rule.Function != function {
+			continue
+		}
+		authorized, err := meetRule(ctx.GetClientIdentity(), rule)
+		if err != nil {
+			return err
+		}
+		if !authorized {
+			return fmt.Errorf("%w to call %s: it should have any of %s", ErrUnauthorized, function, describeRule(rule))
+		}
+	}
+	return nil
+}
+
+// IdentityID returns the ID of the client identity, which is unique within the MSP.
+func IdentityID(ctx contractapi.TransactionContextInterface) (string, error) {
+	identityID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return "", fmt.Errorf("error happened reading the ID of the client identity: %v", err)
+	}
+	return identityID, nil
+}
+
+// -- Internal logics
+
+// meetRule returns whether the identity has any of the roles or the attributes of the rule.
+func meetRule(identity cid.ClientIdentity, rule Rule) (bool, error) {
+	for _, role := range rule.Roles {
+		found, err := hasRole(identity, role)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	for _, attribute := range rule.Attributes {
+		name, value := splitAttribute(attribute)
+		actual, found, err := identity.GetAttributeValue(name)
+		if err != nil {
+			return false, fmt.Errorf("error happened reading the attribute %s of the client identity: %v", name, err)
+		}
+		if found && actual == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// describeRule describes the roles and the attributes required by the rule.
+func describeRule(rule Rule) string {
+	requirements := []string{}
+	if len(rule.Roles) > 0 {
+		requirements = append(requirements, fmt.Sprintf("the roles %v", rule.Roles))
+	}
+	if len(rule.Attributes) > 0 {
+		requirements = append(requirements, fmt.Sprintf("the attributes %v", rule.Attributes))
+	}
+	return strings.Join(requirements, " or ")
+}
+
+// hasRole returns whether the identity has the MSP role, which is given as the OU of the certificate.
+func hasRole(identity cid.ClientIdentity, role string) (bool, error) {
+	cert, err := identity.GetX509Certificate()
+	if err != nil {
+		return false, fmt.Errorf("error happened reading the certificate of the client identity: %v", err)
+	}
+	if cert == nil {
+		return false, nil
+	}
+	return contains(cert.Subject.OrganizationalUnit, role), nil
+}
+
+// splitAttribute splits the attribute in the form of name=value.
+func splitAttribute(attribute string) (string, string) {
+	parts := strings.SplitN(attribute, "=", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func (a *Authorizer) validateRules(rules []Rule) error {
+	functions := map[string]bool{}
+	for _, rule := range rules {
+		if !contains(a.Functions, rule.Function) {
+			return fmt.Errorf("the function '%s' is not supported by the authorization rules", rule.Function)
+		}
+		if functions[rule.Function] {
+			return fmt.Errorf("the authorization rule for %s is duplicated", rule.Function)
+		}
+		functions[rule.Function] = true
+		if len(rule.Roles) == 0 && len(rule.Attributes) == 0 {
+			return fmt.Errorf("the authorization rule for %s should have at least one role or attribute", rule.Function)
+		}
+		for _, role := range rule.Roles {
+			if role == "" {
+				return fmt.Errorf("the authorization rule for %s should not contain an empty role", rule.Function)
+			}
+		}
+		for _, attribute := range rule.Attributes {
+			if name, _ := splitAttribute(attribute); name == "" || !strings.Contains(attribute, "=") {
+				return fmt.Errorf("the attribute '%s' should be in the form of name=value", attribute)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authorization
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetRule(t *testing.T) {
+	identity := &mocks.ClientIdentity{}
+	identity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: []string{"client"}}}, nil)
+	identity.GetAttributeValueStub = func(name string) (string, bool, error) {
+		if name == "opssc.role" {
+			return "voter", true, nil
+		}
+		return "", false, nil
+	}
+
+	tests := []struct {
+		name       string
+		rule       Rule
+		authorized bool
+	}{
+		{name: "role", rule: Rule{Function: "Vote", Roles: []string{"admin", "client"}}, authorized: true},
+		{name: "attribute", rule: Rule{Function: "Vote", Attributes: []string{"opssc.role=voter"}}, authorized: true},
+		{name: "role or attribute", rule: Rule{Function: "Vote", Roles: []string{"admin"}, Attributes: []string{"opssc.role=voter"}}, authorized: true},
+		{name: "other role", rule: Rule{Function: "Vote", Roles: []string{"admin"}}, authorized: false},
+		{name: "other attribute value", rule: Rule{Function: "Vote", Attributes: []string{"opssc.role=operator"}}, authorized: false},
+		{name: "missing attribute", rule: Rule{Function: "Vote", Attributes: []string{"opssc.team=ops"}}, authorized: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorized, err := meetRule(identity, tt.rule)
+			require.NoError(t, err)
+			require.Equal(t, tt.authorized, authorized)
+		})
+	}
+	require.Equal(t, "the roles [admin] or the attributes [opssc.role=voter]",
+		describeRule(Rule{Function: "Vote", Roles: []string{"admin"}, Attributes: []string{"opssc.role=voter"}}))
+}
+
+func TestValidateRules(t *testing.T) {
+	authorizer := &Authorizer{Functions: []string{"Vote", "RequestProposal"}}
+
+	require.NoError(t, authorizer.validateRules(nil))
+	require.NoError(t, authorizer.validateRules([]Rule{
+		{Function: "Vote", Attributes: []string{"opssc.role=voter"}},
+		{Function: "RequestProposal", Roles: []string{"admin"}},
+	}))
+
+	tests := []struct {
+		name   string
+		rules  []Rule
+		errMsg string
+	}{
+		{
+			name:   "unsupported function",
+			rules:  []Rule{{Function: "GetProposal", Roles: []string{"admin"}}},
+			errMsg: "the function 'GetProposal' is not supported by the authorization rules",
+		},
+		{
+			name:   "duplicated function",
+			rules:  []Rule{{Function: "Vote", Roles: []string{"admin"}}, {Function: "Vote", Roles: []string{"client"}}},
+			errMsg: "the authorization rule for Vote is duplicated",
+		},
+		{
+			name:   "no role and attribute",
+			rules:  []Rule{{Function: "Vote"}},
+			errMsg: "the authorization rule for Vote should have at least one role or attribute",
+		},
+		{
+			name:   "empty role",
+			rules:  []Rule{{Function: "Vote", Roles: []string{""}}},
+			errMsg: "the authorization rule for Vote should not contain an empty role",
+		},
+		{
+			name:   "attribute without value",
+			rules:  []Rule{{Function: "Vote", Attributes: []string{"opssc.role"}}},
+			errMsg: "the attribute 'opssc.role' should be in the form of name=value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.EqualError(t, authorizer.validateRules(tt.rules), tt.errMsg)
+		})
+	}
+}
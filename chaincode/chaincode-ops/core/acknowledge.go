@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// meetAcknowledgeCriteria checks whether the proposal meets the acknowledge criteria in the voting config for the channel.
+// It also returns the orgs in the channel which have not acknowledged the deployment yet.
+func (s *SmartContract) meetAcknowledgeCriteria(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, currentHistory History) (bool, []string, error) {
+	votingConfig, err := s.GetEffectiveVotingConfig(ctx, proposal.ChannelID)
+	if err != nil {
+		return false, nil, err
+	}
+	criteria := AckByAll
+	if votingConfig != nil && votingConfig.AcknowledgeCriteria != "" {
+		criteria = votingConfig.AcknowledgeCriteria
+	}
+
+	channelOrgs, notAcknowledgedOrgs, err := s.getNotAcknowledgedOrgs(ctx, proposal, currentHistory.OrgID)
+	if err != nil {
+		return false, nil, err
+	}
+	acknowledgedNum := len(channelOrgs) - len(notAcknowledgedOrgs)
+
+	switch criteria {
+	case AckByAll:
+		return len(notAcknowledgedOrgs) == 0, notAcknowledgedOrgs, nil
+	case AckByMajority:
+		return acknowledgedNum >= len(channelOrgs)/2+1, notAcknowledgedOrgs, nil
+	case AckByCount:
+		criteriaNum := votingConfig.AcknowledgeCount
+		if criteriaNum > len(channelOrgs) {
+			criteriaNum = len(channelOrgs)
+		}
+		return acknowledgedNum >= criteriaNum, notAcknowledgedOrgs, nil
+	case AckByAgreedVoters:
+		// The votes for the members of a release are recorded on the release
+		voteProposalID, voteTaskID := proposal.ID, Vote
+		if proposal.ReleaseID != "" {
+			voteProposalID, voteTaskID = proposal.ReleaseID, ReleaseVote
+		}
+		voters, err := s.getOrgsWithTaskStatus(ctx, voteProposalID, voteTaskID, Agreed)
+		if err != nil {
+			return false, nil, err
+		}
+		for _, mspID := range notAcknowledgedOrgs {
+			if voters[mspID] {
+				return false, notAcknowledgedOrgs, nil
+			}
+		}
+		return true, notAcknowledgedOrgs, nil
+	default:
+		return false, nil, fmt.Errorf("invalid acknowledge criteria type: %v", criteria)
+	}
+}
+
+// getNotAcknowledgedOrgs returns the orgs in the channel and the orgs in them which have not acknowledged the deployment yet.
+// acknowledgedOrg is the org which acknowledges the deployment in the current transaction (if any).
+func (s *SmartContract) getNotAcknowledgedOrgs(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, acknowledgedOrg string) ([]string, []string, error) {
+	channelOrgs, err := s.getOrganizationsInChannel(ctx, proposal.ChannelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	acknowledged, err := s.getOrgsWithTaskStatus(ctx, proposal.ID, Acknowledge, Success)
+	if err != nil {
+		return nil, nil, err
+	}
+	if acknowledgedOrg != "" {
+		acknowledged[acknowledgedOrg] = true
+	}
+
+	notAcknowledgedOrgs := []string{}
+	for _, mspID := range channelOrgs {
+		if !acknowledged[mspID] {
+			notAcknowledgedOrgs = append(notAcknowledgedOrgs, mspID)
+		}
+	}
+	return channelOrgs, notAcknowledgedOrgs, nil
+}
+
+// getOrgsWithTaskStatus returns the orgs whose histories for the task of the proposal have the given status.
+func (s *SmartContract) getOrgsWithTaskStatus(ctx contractapi.TransactionContextInterface, proposalID string, taskID string, status string) (map[string]bool, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(HistoryObjectType, []string{proposalID, taskID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	orgs := map[string]bool{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available histories: %v", err)
+		}
+		var history History
+		if err = json.Unmarshal(result.Value, &history); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+		}
+		if history.Status == status {
+			orgs[history.OrgID] = true
+		}
+	}
+	return orgs, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+var org3MSP = marshalProtoOrPanic(&msp.SerializedIdentity{Mspid: "Org3MSP", IdBytes: []byte("myid")})
+
+// invokeChaincodeWithThreeOrgs is a dummy implementation of chaincode-to-chaincode calls for a channel with 3 orgs.
+func invokeChaincodeWithThreeOrgs(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
+	switch string(arg2[0]) {
+	case "GetOrganizationsInChannel":
+		return peer.Response{Status: shim.OK, Payload: []byte(`["Org1MSP","Org2MSP","Org3MSP"]`)}
+	case "CountOrganizationsInChannel":
+		return peer.Response{Status: shim.OK, Payload: []byte("3")}
+	}
+	return invokeChaincode(arg1, arg2, arg3)
+}
+
+// setVotingConfigByGovernance sets the voting config through a governance proposal voted by Org1MSP and Org2MSP.
+func setVotingConfigByGovernance(t *testing.T, sc *SmartContract, transactionContext *mocks.TransactionContext, chaincodeStub *mocks.ChaincodeStub, id string, votingConfig VotingConfig) {
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err := sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{ID: id, VotingConfig: votingConfig})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: id})
+	require.NoError(t, err)
+}
+
+func TestAcknowledgeWithCriteria(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	acknowledge := func(creator []byte, proposalID string) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		err := sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID})
+		require.NoError(t, err)
+	}
+	requestApprovedProposal := func(proposalID string) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = proposalID
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, Approved, proposal.Status)
+	}
+	requireDeployed := func(proposalID string, committer string, notAcknowledgedOrgs []string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, Acknowledged, proposal.Status)
+
+		eventName, eventPayload := lastEvent(chaincodeStub)
+		require.Equal(t, "deployEvent."+proposalID, eventName)
+		expectedJSON, err := json.Marshal(DeploymentEventDetail{
+			Proposal:            *proposal,
+			OperationTargets:    []string{committer},
+			NotAcknowledgedOrgs: notAcknowledgedOrgs,
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(eventPayload))
+	}
+	requireNotDeployed := func(proposalID string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, Approved, proposal.Status)
+	}
+
+	// Case: The deployment is acknowledged by a majority of the orgs in the channel
+	setVotingConfigByGovernance(t, sc, transactionContext, chaincodeStub, "governance-1", VotingConfig{ChannelID: "mychannel", AcknowledgeCriteria: AckByMajority})
+	requestApprovedProposal("request-1")
+	acknowledge(org1MSP, "request-1")
+	requireNotDeployed("request-1")
+	acknowledge(org2MSP, "request-1")
+	requireDeployed("request-1", "Org2MSP", []string{"Org3MSP"})
+
+	// Case: The deployment is acknowledged by all orgs which agreed to the proposal
+	setVotingConfigByGovernance(t, sc, transactionContext, chaincodeStub, "governance-2", VotingConfig{ChannelID: "mychannel", AcknowledgeCriteria: AckByAgreedVoters})
+	requestApprovedProposal("request-2")
+	acknowledge(org2MSP, "request-2")
+	acknowledge(org3MSP, "request-2")
+	requireNotDeployed("request-2")
+	acknowledge(org1MSP, "request-2")
+	requireDeployed("request-2", "Org1MSP", []string{})
+
+	// Case: The deployment is acknowledged by the given number of orgs
+	setVotingConfigByGovernance(t, sc, transactionContext, chaincodeStub, "governance-3", VotingConfig{ChannelID: "mychannel", AcknowledgeCriteria: AckByCount, AcknowledgeCount: 1})
+	requestApprovedProposal("request-3")
+	acknowledge(org3MSP, "request-3")
+	requireDeployed("request-3", "Org3MSP", []string{"Org1MSP", "Org2MSP"})
+
+	// Case: The deployment is acknowledged by all orgs by default
+	setVotingConfigByGovernance(t, sc, transactionContext, chaincodeStub, "governance-4", VotingConfig{ChannelID: "mychannel"})
+	requestApprovedProposal("request-4")
+	acknowledge(org1MSP, "request-4")
+	acknowledge(org2MSP, "request-4")
+	requireNotDeployed("request-4")
+	acknowledge(org3MSP, "request-4")
+	requireDeployed("request-4", "Org3MSP", []string{})
+}
+
+func TestAcknowledgeByAgreedVotersForReleaseMembers(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	acknowledge := func(creator []byte, proposalID string) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		err := sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID})
+		require.NoError(t, err)
+	}
+	requireStatus := func(proposalID string, status string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, proposal.Status)
+	}
+
+	// Prepare: The release approved by the vote of the proposer (Org1MSP)
+	setVotingConfigByGovernance(t, sc, transactionContext, chaincodeStub, "governance-1", VotingConfig{ChannelID: "mychannel", AcknowledgeCriteria: AckByAgreedVoters})
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	input := ReleaseProposalInput{ID: "release-1"}
+	for _, memberID := range []string{"member-1", "member-2"} {
+		_, member := baseProposalAndInput("")
+		member.ID = memberID
+		member.ChaincodeName = memberID
+		input.Members = append(input.Members, member)
+	}
+	_, err := sc.RequestReleaseProposal(transactionContext, input)
+	require.NoError(t, err)
+	requireStatus("member-1", Approved)
+
+	// Case: The member is not acknowledged until all orgs which agreed to the release acknowledge it
+	acknowledge(org3MSP, "member-1")
+	requireStatus("member-1", Approved)
+	acknowledge(org2MSP, "member-1")
+	requireStatus("member-1", Approved)
+	acknowledge(org1MSP, "member-1")
+	requireStatus("member-1", Acknowledged)
+}
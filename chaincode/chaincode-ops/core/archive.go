@@ -0,0 +1,315 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Functionalities to archive the finished proposals.
+// The archived proposal is moved with its task histories into a single archive key,
+// so that the range scans over the proposals and the histories do not get slower as the proposals finish.
+// The archived proposals are also indexed per channel with their summaries.
+// The superseded votes are kept as they are, since they are the audit records.
+
+// ArchivedProposal is the archive of a finished chaincode update proposal that is stored as a state in the ledger.
+type ArchivedProposal struct {
+	ObjectType  string                  `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	Proposal    ChaincodeUpdateProposal `json:"proposal"`
+	Histories   []History               `json:"histories"`  // the task histories of the proposal (ordered by the task ID and the org ID)
+	ArchivedBy  string                  `json:"archivedBy"` // the MSP ID of the org which archived the proposal
+	ArchiveTxID string                  `json:"archiveTxID"`
+	ArchivedAt  string                  `json:"archivedAt"` // RFC3339
+}
+
+// ArchivedProposalSummary is an entry of the per-channel index of the archived proposals.
+type ArchivedProposalSummary struct {
+	ObjectType    string `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	ProposalID    string `json:"proposalID"`
+	ChannelID     string `json:"channelID"`
+	ChaincodeName string `json:"chaincodeName"`
+	Sequence      int64  `json:"sequence"`
+	Creator       string `json:"creator"`
+	Status        string `json:"status"`
+	Time          string `json:"time"`       // the time when the proposal was requested (RFC3339)
+	ArchivedAt    string `json:"archivedAt"` // RFC3339
+}
+
+// Object types
+const (
+	ArchivedProposalObjectType = "archivedProposal"
+	ArchiveIndexObjectType     = "archiveIndex"
+)
+
+// finishedStatuses are the statuses of the proposals which can be archived.
+// The failed proposal is not regarded as finished because it can be retried.
+var finishedStatuses = []string{Committed, Rejected, Withdrawn, Expired}
+
+// ArchiveProposals archives the finished proposals requested before the given time.
+// Each proposal is removed from stateDB with its task histories, and it is put to the archive and the per-channel index instead.
+// The members of a release are archived only after the release is finished.
+// Before the committed proposals are archived, the chaincode states are recorded to keep the last committed sequences.
+//
+// Arguments:
+//   0: before - the proposals requested before this time are archived (RFC3339)
+//   1: statuses - the statuses of the proposals to be archived (all of committed, rejected, withdrawn and expired if empty)
+//
+// Returns:
+//   0: the list of the IDs of the archived proposals
+//   1: error
+//
+func (s *SmartContract) ArchiveProposals(ctx contractapi.TransactionContextInterface, before string, statuses []string) ([]string, error) {
+
+	if err := s.authorize(ctx, "ArchiveProposals"); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if before == "" {
+		return nil, fmt.Errorf("the required parameter 'before' is empty")
+	}
+	beforeTime, err := time.Parse(time.RFC3339, before)
+	if err != nil {
+		return nil, fmt.Errorf("the parameter 'before' should be RFC3339: %v", err)
+	}
+	if len(statuses) == 0 {
+		statuses = finishedStatuses
+	}
+	targetStatuses := map[string]bool{}
+	for _, status := range statuses {
+		if !contains(finishedStatuses, status) {
+			return nil, fmt.Errorf("the parameter 'statuses' should only contain %v", finishedStatuses)
+		}
+		targetStatuses[status] = true
+	}
+
+	proposals, err := s.GetAllProposals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposals: %v", err)
+	}
+	keys := []string{}
+	for key, proposal := range proposals {
+		if !targetStatuses[proposal.Status] {
+			continue
+		}
+		requestedTime, err := time.Parse(time.RFC3339, proposal.Time)
+		if err != nil || !requestedTime.Before(beforeTime) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	archivedIDs := []string{}
+	recordedStates := map[string]bool{}
+	for _, key := range keys {
+		proposal := proposals[key]
+		if proposal.ReleaseID != "" {
+			release, err := s.GetReleaseProposal(ctx, proposal.ReleaseID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the release (%s): %v", proposal.ReleaseID, err)
+			}
+			if !contains(finishedStatuses, release.Status) {
+				continue
+			}
+		}
+
+		// Record the chaincode state, which is otherwise built from the committed proposals
+		stateKey := proposal.ChannelID + "/" + proposal.ChaincodeName
+		if proposal.Status == Committed && !recordedStates[stateKey] {
+			state, err := s.getChaincodeState(ctx, proposal.ChannelID, proposal.ChaincodeName)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.putChaincodeState(ctx, *state); err != nil {
+				return nil, err
+			}
+			recordedStates[stateKey] = true
+		}
+
+		archived := ArchivedProposal{
+			ObjectType:  ArchivedProposalObjectType,
+			Proposal:    *proposal,
+			ArchivedBy:  mspID,
+			ArchiveTxID: ctx.GetStub().GetTxID(),
+			ArchivedAt:  txTimestamp,
+		}
+		if archived.Histories, err = s.delHistories(ctx, proposal.ID); err != nil {
+			return nil, err
+		}
+		if err := s.putArchivedProposal(ctx, archived); err != nil {
+			return nil, err
+		}
+		if err := ctx.GetStub().DelState(key); err != nil {
+			return nil, fmt.Errorf("error happened deleting the proposal: %v", err)
+		}
+		archivedIDs = append(archivedIDs, proposal.ID)
+	}
+	return archivedIDs, nil
+}
+
+// GetArchivedProposal returns the archived proposal with the given ID.
+//
+// Arguments:
+//   0: proposalID - the ID of the archived proposal
+//
+// Returns:
+//   0: the archived proposal with the given ID
+//   1: error
+//
+func (s *SmartContract) GetArchivedProposal(ctx contractapi.TransactionContextInterface, proposalID string) (*ArchivedProposal, error) {
+	if proposalID == "" {
+		return nil, fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ArchivedProposalObjectType, []string{proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for archived proposal: %v", err)
+	}
+	archivedJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading archived proposal with id (%v): %v", proposalID, err)
+	}
+	if archivedJSON == nil {
+		return nil, ErrProposalNotFound
+	}
+
+	var archived ArchivedProposal
+	if err = json.Unmarshal(archivedJSON, &archived); err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling an archived proposal JSON representation to struct: %v", err)
+	}
+	return &archived, nil
+}
+
+// GetArchivedProposalSummaries returns the summaries of the archived proposals for the given channel from the per-channel index.
+//
+// Arguments:
+//   0: channelID - the channel ID targeted by the archived proposals
+//   1: chaincodeName - the chaincode name targeted by the archived proposals (all chaincodes in the channel if empty)
+//
+// Returns:
+//   0: the summaries of the archived proposals (ordered by the chaincode name and the proposal ID)
+//   1: error
+//
+func (s *SmartContract) GetArchivedProposalSummaries(ctx contractapi.TransactionContextInterface, channelID string, chaincodeName string) ([]*ArchivedProposalSummary, error) {
+	if channelID == "" {
+		return nil, fmt.Errorf("the required parameter 'channelID' is empty")
+	}
+	attributes := []string{channelID}
+	if chaincodeName != "" {
+		attributes = append(attributes, chaincodeName)
+	}
+
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(ArchiveIndexObjectType, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	summaries := []*ArchivedProposalSummary{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available archived proposals: %v", err)
+		}
+		var summary ArchivedProposalSummary
+		if err = json.Unmarshal(result.Value, &summary); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling an archived proposal summary JSON representation to struct: %v", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+	return summaries, nil
+}
+
+// getProposalOrArchived returns the proposal with the given ID, which is read from the archive if the proposal is already archived.
+func (s *SmartContract) getProposalOrArchived(ctx contractapi.TransactionContextInterface, proposalID string) (*ChaincodeUpdateProposal, error) {
+	proposal, err := s.GetProposal(ctx, proposalID)
+	if err != ErrProposalNotFound {
+		return proposal, err
+	}
+	archived, err := s.GetArchivedProposal(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	return &archived.Proposal, nil
+}
+
+// delHistories deletes the task histories of the proposal from stateDB and returns them ordered by the key.
+func (s *SmartContract) delHistories(ctx contractapi.TransactionContextInterface, proposalID string) ([]History, error) {
+	histories, err := s.GetHistories(ctx, HistoryQueryParams{ProposalID: proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the histories: %v", err)
+	}
+	keys := make([]string, 0, len(histories))
+	for key := range histories {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	deleted := []History{}
+	for _, key := range keys {
+		if err := ctx.GetStub().DelState(key); err != nil {
+			return nil, fmt.Errorf("error happened deleting the history: %v", err)
+		}
+		deleted = append(deleted, *histories[key])
+	}
+	return deleted, nil
+}
+
+// putArchivedProposal puts the archived proposal and its summary in the per-channel index.
+func (s *SmartContract) putArchivedProposal(ctx contractapi.TransactionContextInterface, archived ArchivedProposal) error {
+	proposal := archived.Proposal
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ArchivedProposalObjectType, []string{proposal.ID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for archived proposal: %v", err)
+	}
+	archivedJSON, err := json.Marshal(archived)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the archived proposal: %v", err)
+	}
+	if err := ctx.GetStub().PutState(compositeKey, archivedJSON); err != nil {
+		return fmt.Errorf("error happened persisting the archived proposal on the ledger: %v", err)
+	}
+
+	summary := ArchivedProposalSummary{
+		ObjectType:    ArchiveIndexObjectType,
+		ProposalID:    proposal.ID,
+		ChannelID:     proposal.ChannelID,
+		ChaincodeName: proposal.ChaincodeName,
+		Sequence:      proposal.ChaincodeDefinition.Sequence,
+		Creator:       proposal.Creator,
+		Status:        proposal.Status,
+		Time:          proposal.Time,
+		ArchivedAt:    archived.ArchivedAt,
+	}
+	indexKey, err := ctx.GetStub().CreateCompositeKey(ArchiveIndexObjectType, []string{proposal.ChannelID, proposal.ChaincodeName, proposal.ID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for archive index: %v", err)
+	}
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the archived proposal summary: %v", err)
+	}
+	if err := ctx.GetStub().PutState(indexKey, summaryJSON); err != nil {
+		return fmt.Errorf("error happened persisting the archived proposal summary on the ledger: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestArchiveProposals(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxIDReturns("tx-1")
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	ws := newWorldState(chaincodeStub)
+	newKeyHistory(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	requestProposal := func(proposalID string, chaincodeName string) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = chaincodeName
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+	}
+	vote := func(proposalID string, status string) {
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID, Status: status}))
+	}
+	commit := func(proposalID string) {
+		vote(proposalID, Agreed)
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+		require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+	}
+
+	// Prepare: The finished proposals and the open one requested before the archive time, and the finished one requested after it
+	requestProposal("deploy-committed", "basic")
+	commit("deploy-committed")
+	requestProposal("deploy-rejected", "another")
+	vote("deploy-rejected", Disagreed)
+	requestProposal("deploy-open", "open")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(2*time.Hour)), nil)
+	requestProposal("deploy-later", "later")
+	vote("deploy-later", Disagreed)
+	before := now.Add(time.Hour).Format(time.RFC3339)
+
+	// Case: Archive only the committed proposals
+	chaincodeStub.GetTxIDReturns("tx-archive")
+	archivedIDs, err := sc.ArchiveProposals(transactionContext, before, []string{Committed})
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy-committed"}, archivedIDs)
+
+	_, err = sc.GetProposal(transactionContext, "deploy-committed")
+	require.ErrorIs(t, err, ErrProposalNotFound)
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "deploy-committed"})
+	require.NoError(t, err)
+	require.Empty(t, histories)
+
+	archived, err := sc.GetArchivedProposal(transactionContext, "deploy-committed")
+	require.NoError(t, err)
+	require.Equal(t, ArchivedProposalObjectType, archived.ObjectType)
+	require.Equal(t, "deploy-committed", archived.Proposal.ID)
+	require.Equal(t, Committed, archived.Proposal.Status)
+	require.Equal(t, "Org2MSP", archived.ArchivedBy)
+	require.Equal(t, "tx-archive", archived.ArchiveTxID)
+	require.Equal(t, now.Add(2*time.Hour).UTC().Format(time.RFC3339), archived.ArchivedAt)
+	require.Len(t, archived.Histories, 5)
+	require.Equal(t, Acknowledge, archived.Histories[0].TaskID)
+	require.Equal(t, Commit, archived.Histories[2].TaskID)
+	require.Equal(t, Vote, archived.Histories[4].TaskID)
+
+	// Case: The last committed sequence is kept after the committed proposal is archived
+	state, err := sc.GetChaincodeState(transactionContext, "mychannel", "basic")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), state.LastCommittedSequence)
+
+	// Case: The audit trail of the archived proposal is still available
+	records, err := sc.GetProposalAuditTrail(transactionContext, "deploy-committed")
+	require.NoError(t, err)
+	lastRecord := records[len(records)-1]
+	require.Equal(t, "tx-archive", lastRecord.TxID)
+	require.True(t, lastRecord.IsDelete)
+	historyRecords := 0
+	for _, record := range records {
+		if record.ObjectType == HistoryObjectType {
+			historyRecords++
+		}
+	}
+	require.Equal(t, 10, historyRecords)
+
+	// Case: Archive all the finished proposals (the open one and the one requested later are kept)
+	archivedIDs, err = sc.ArchiveProposals(transactionContext, before, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy-rejected"}, archivedIDs)
+	for _, proposalID := range []string{"deploy-open", "deploy-later"} {
+		_, err = sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+	}
+
+	// Case: Get the summaries of the archived proposals in the channel
+	summaries, err := sc.GetArchivedProposalSummaries(transactionContext, "mychannel", "")
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	require.Equal(t, "deploy-rejected", summaries[0].ProposalID)
+	require.Equal(t, "another", summaries[0].ChaincodeName)
+	require.Equal(t, Rejected, summaries[0].Status)
+	require.Equal(t, "deploy-committed", summaries[1].ProposalID)
+	require.Equal(t, int64(1), summaries[1].Sequence)
+	require.Equal(t, now.UTC().Format(time.RFC3339), summaries[1].Time)
+
+	summaries, err = sc.GetArchivedProposalSummaries(transactionContext, "mychannel", "basic")
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	summaries, err = sc.GetArchivedProposalSummaries(transactionContext, "otherchannel", "")
+	require.NoError(t, err)
+	require.Empty(t, summaries)
+
+	// Case: The archived proposal ID cannot be reused
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	input.ID = "deploy-committed"
+	input.ChaincodeName = "reuse"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.ErrorIs(t, err, ErrProposalIDAreadyInUse)
+
+	// Case: The archived proposal can be a dependency
+	input.ID = "deploy-dependent"
+	input.DependsOn = []string{"deploy-committed"}
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	// Case: Nothing is archived twice
+	keys := len(ws)
+	archivedIDs, err = sc.ArchiveProposals(transactionContext, before, nil)
+	require.NoError(t, err)
+	require.Empty(t, archivedIDs)
+	require.Len(t, ws, keys)
+}
+
+func TestArchiveReleaseMembers(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+	before := now.Add(time.Hour).Format(time.RFC3339)
+
+	// Prepare: Only one of the members of the release is committed
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	input := ReleaseProposalInput{ID: "release-1"}
+	for _, memberID := range []string{"member-1", "member-2"} {
+		_, member := baseProposalAndInput("")
+		member.ID = memberID
+		member.ChaincodeName = memberID
+		input.Members = append(input.Members, member)
+	}
+	_, err := sc.RequestReleaseProposal(transactionContext, input)
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-1"}))
+	for _, memberID := range []string{"member-1", "member-2"} {
+		for _, creator := range [][]byte{org1MSP, org2MSP} {
+			chaincodeStub.GetCreatorReturns(creator, nil)
+			require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: memberID}))
+		}
+	}
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "member-1"}))
+
+	// Case: The committed member is not archived until the release is finished
+	archivedIDs, err := sc.ArchiveProposals(transactionContext, before, nil)
+	require.NoError(t, err)
+	require.Empty(t, archivedIDs)
+
+	// Case: All the members are archived after the release is committed
+	require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "member-2"}))
+	archivedIDs, err = sc.ArchiveProposals(transactionContext, before, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"member-1", "member-2"}, archivedIDs)
+	release, err := sc.GetReleaseProposal(transactionContext, "release-1")
+	require.NoError(t, err)
+	require.Equal(t, Committed, release.Status)
+}
+
+func TestArchiveProposalsWithInvalidInput(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	_, err := sc.ArchiveProposals(transactionContext, "", nil)
+	require.EqualError(t, err, "the required parameter 'before' is empty")
+
+	_, err = sc.ArchiveProposals(transactionContext, "2026-01-01", nil)
+	require.EqualError(t, err, `the parameter 'before' should be RFC3339: parsing time "2026-01-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`)
+
+	_, err = sc.ArchiveProposals(transactionContext, "2026-01-01T00:00:00Z", []string{Committed, Failed})
+	require.EqualError(t, err, "the parameter 'statuses' should only contain [committed rejected withdrawn expired]")
+
+	_, err = sc.GetArchivedProposal(transactionContext, "deploy-unknown")
+	require.ErrorIs(t, err, ErrProposalNotFound)
+
+	_, err = sc.GetArchivedProposalSummaries(transactionContext, "", "")
+	require.EqualError(t, err, "the required parameter 'channelID' is empty")
+}
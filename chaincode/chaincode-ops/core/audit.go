@@ -0,0 +1,204 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AuditRecord represents a state transition of a key related to a chaincode update proposal, which is read from the key history.
+type AuditRecord struct {
+	ObjectType string `json:"objectType"`                            // ProposalObjectType or HistoryObjectType
+	TaskID     string `json:"taskID,omitempty" metadata:",optional"` // the task of the history (only for HistoryObjectType)
+	OrgID      string `json:"orgID,omitempty" metadata:",optional"`  // the org of the history (only for HistoryObjectType)
+	Status     string `json:"status,omitempty" metadata:",optional"` // the status after the transition (empty if the key was deleted)
+	TxID       string `json:"txID"`
+	Time       string `json:"time"`                                     // the timestamp of the transaction (RFC3339)
+	Submitter  string `json:"submitter,omitempty" metadata:",optional"` // the MSP ID of the org which submitted the transaction
+	IsDelete   bool   `json:"isDelete"`
+}
+
+// auditTarget is a key whose history is read for the audit trail.
+type auditTarget struct {
+	key        string
+	objectType string
+	taskID     string
+	orgID      string
+}
+
+// timedAuditRecord is an audit record with the timestamp in full precision, which is used for ordering the records.
+type timedAuditRecord struct {
+	record    *AuditRecord
+	timestamp time.Time
+}
+
+// GetProposalAuditTrail returns every state transition of the chaincode update proposal and its histories (votes, acknowledgments and commits),
+// which is read from the key history of the ledger.
+// The retracted votes are also included as the transitions deleting the history.
+// Note that the key history is only available on the peers with the history database enabled (core.ledger.history.enableHistoryDatabase).
+//
+// Arguments:
+//   0: proposalID - the ID for the chaincode update proposal
+//
+// Returns:
+//   0: the state transitions (ordered by the timestamp of the transaction)
+//   1: error
+//
+func (s *SmartContract) GetProposalAuditTrail(ctx contractapi.TransactionContextInterface, proposalID string) ([]*AuditRecord, error) {
+	if proposalID == "" {
+		return nil, fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	targets, err := s.getAuditTargets(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	timedRecords := []timedAuditRecord{}
+	for _, target := range targets {
+		targetRecords, err := s.getAuditRecords(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		timedRecords = append(timedRecords, targetRecords...)
+	}
+	if len(timedRecords) == 0 {
+		return nil, ErrProposalNotFound
+	}
+
+	sort.SliceStable(timedRecords, func(i, j int) bool {
+		return timedRecords[i].timestamp.Before(timedRecords[j].timestamp)
+	})
+	records := make([]*AuditRecord, 0, len(timedRecords))
+	for _, timedRecord := range timedRecords {
+		records = append(records, timedRecord.record)
+	}
+	return records, nil
+}
+
+// getAuditTargets returns the proposal key and the history keys of the proposal, including the keys of the retracted votes.
+func (s *SmartContract) getAuditTargets(ctx contractapi.TransactionContextInterface, proposalID string) ([]auditTarget, error) {
+	proposalKey, err := ctx.GetStub().CreateCompositeKey(ProposalObjectType, []string{proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for proposal: %v", err)
+	}
+	targets := []auditTarget{{key: proposalKey, objectType: ProposalObjectType}}
+
+	historyKeys := map[string]auditTarget{}
+	addHistoryKey := func(taskID string, orgID string) error {
+		key, err := ctx.GetStub().CreateCompositeKey(HistoryObjectType, []string{proposalID, taskID, orgID})
+		if err != nil {
+			return fmt.Errorf("error happened creating composite key for history: %v", err)
+		}
+		historyKeys[key] = auditTarget{key: key, objectType: HistoryObjectType, taskID: taskID, orgID: orgID}
+		return nil
+	}
+
+	histories, err := s.GetHistories(ctx, HistoryQueryParams{ProposalID: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	for _, history := range histories {
+		if err := addHistoryKey(history.TaskID, history.OrgID); err != nil {
+			return nil, err
+		}
+	}
+	// The histories of the archived proposal are no longer in the world state, but their keys are found from the archive
+	archived, err := s.GetArchivedProposal(ctx, proposalID)
+	if err != nil && err != ErrProposalNotFound {
+		return nil, err
+	}
+	if archived != nil {
+		for _, history := range archived.Histories {
+			if err := addHistoryKey(history.TaskID, history.OrgID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// The votes retracted are no longer in the world state, but their keys are found from the superseded votes
+	supersededVotes, err := s.GetSupersededVotes(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	for _, supersededVote := range supersededVotes {
+		if err := addHistoryKey(Vote, supersededVote.Vote.OrgID); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(historyKeys))
+	for key := range historyKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		targets = append(targets, historyKeys[key])
+	}
+	return targets, nil
+}
+
+// getAuditRecords returns the state transitions of the given key.
+func (s *SmartContract) getAuditRecords(ctx contractapi.TransactionContextInterface, target auditTarget) ([]timedAuditRecord, error) {
+	iterator, err := ctx.GetStub().GetHistoryForKey(target.key)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading the key history from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	records := []timedAuditRecord{}
+	for iterator.HasNext() {
+		modification, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over the key history: %v", err)
+		}
+		record := &AuditRecord{
+			ObjectType: target.objectType,
+			TaskID:     target.taskID,
+			OrgID:      target.orgID,
+			TxID:       modification.TxId,
+			IsDelete:   modification.IsDelete,
+		}
+		var timestamp time.Time
+		if modification.Timestamp != nil {
+			timestamp = time.Unix(modification.Timestamp.Seconds, int64(modification.Timestamp.Nanos))
+			record.Time = timestamp.UTC().Format(time.RFC3339)
+		}
+
+		switch target.objectType {
+		case ProposalObjectType:
+			// The proposal records the org which submitted the last update
+			if !modification.IsDelete {
+				var proposal ChaincodeUpdateProposal
+				if err := json.Unmarshal(modification.Value, &proposal); err != nil {
+					return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+				}
+				record.Status = proposal.Status
+				record.Submitter = proposal.UpdatedBy
+			}
+		case HistoryObjectType:
+			// Each org only puts (and deletes) its own histories, except for the votes by the delegates
+			record.Submitter = target.orgID
+			if !modification.IsDelete {
+				var history History
+				if err := json.Unmarshal(modification.Value, &history); err != nil {
+					return nil, fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+				}
+				record.Status = history.Status
+				if history.Delegate != "" {
+					record.Submitter = history.Delegate
+				}
+			}
+		}
+		records = append(records, timedAuditRecord{record: record, timestamp: timestamp})
+	}
+	return records, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// keyHistory is a dummy key history which records the modifications of the keys in the world state.
+type keyHistory map[string][]*queryresult.KeyModification
+
+// newKeyHistory wraps the world state set to the given stub to record the modifications of the keys with the tx ID and the tx timestamp.
+func newKeyHistory(chaincodeStub *mocks.ChaincodeStub) keyHistory {
+	kh := keyHistory{}
+	record := func(key string, value []byte, isDelete bool) {
+		timestamp, _ := chaincodeStub.GetTxTimestamp()
+		kh[key] = append(kh[key], &queryresult.KeyModification{TxId: chaincodeStub.GetTxID(), Value: value, Timestamp: timestamp, IsDelete: isDelete})
+	}
+	putState, delState := chaincodeStub.PutStateStub, chaincodeStub.DelStateStub
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		record(key, value, false)
+		return putState(key, value)
+	}
+	chaincodeStub.DelStateStub = func(key string) error {
+		record(key, nil, true)
+		return delState(key)
+	}
+	chaincodeStub.GetHistoryForKeyStub = func(key string) (shim.HistoryQueryIteratorInterface, error) {
+		// Like the peer, return the modifications from the newest one
+		modifications := kh[key]
+		iterator := &mocks.HistoryQueryIterator{}
+		index := len(modifications) - 1
+		iterator.HasNextStub = func() bool {
+			return index >= 0
+		}
+		iterator.NextStub = func() (*queryresult.KeyModification, error) {
+			modification := modifications[index]
+			index--
+			return modification, nil
+		}
+		return iterator, nil
+	}
+	return kh
+}
+
+func TestGetProposalAuditTrail(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	newWorldState(chaincodeStub)
+	newKeyHistory(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	now := time.Now().Truncate(time.Second)
+	submit := func(txID string, creator []byte, elapsed time.Duration) {
+		chaincodeStub.GetTxIDReturns(txID)
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(elapsed)), nil)
+	}
+	timeAt := func(elapsed time.Duration) string {
+		return now.Add(elapsed).UTC().Format(time.RFC3339)
+	}
+
+	// Prepare: The proposal is approved after the vote by Org2 is retracted
+	submit("tx-1", org1MSP, 0)
+	_, input := baseProposalAndInput("")
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	submit("tx-2", org2MSP, time.Second)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Disagreed}))
+	submit("tx-3", org2MSP, 2*time.Second)
+	require.NoError(t, sc.RetractVote(transactionContext, "request-1"))
+	submit("tx-4", org3MSP, 3*time.Second)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Agreed}))
+
+	// Case: The audit trail contains every transition in order including the retracted vote
+	records, err := sc.GetProposalAuditTrail(transactionContext, "request-1")
+	require.NoError(t, err)
+	expected := []*AuditRecord{
+		{ObjectType: ProposalObjectType, Status: Proposed, TxID: "tx-1", Time: timeAt(0), Submitter: "Org1MSP"},
+		{ObjectType: HistoryObjectType, TaskID: Vote, OrgID: "Org1MSP", Status: Agreed, TxID: "tx-1", Time: timeAt(0), Submitter: "Org1MSP"},
+		{ObjectType: HistoryObjectType, TaskID: Vote, OrgID: "Org2MSP", Status: Disagreed, TxID: "tx-2", Time: timeAt(time.Second), Submitter: "Org2MSP"},
+		{ObjectType: HistoryObjectType, TaskID: Vote, OrgID: "Org2MSP", TxID: "tx-3", Time: timeAt(2 * time.Second), Submitter: "Org2MSP", IsDelete: true},
+		{ObjectType: ProposalObjectType, Status: Approved, TxID: "tx-4", Time: timeAt(3 * time.Second), Submitter: "Org3MSP"},
+		{ObjectType: HistoryObjectType, TaskID: Vote, OrgID: "Org3MSP", Status: Agreed, TxID: "tx-4", Time: timeAt(3 * time.Second), Submitter: "Org3MSP"},
+	}
+	require.Equal(t, expected, records)
+
+	// Case: Fail to get the audit trail of the unknown proposal
+	_, err = sc.GetProposalAuditTrail(transactionContext, "request-unknown")
+	require.ErrorIs(t, err, ErrProposalNotFound)
+
+	// Case: Fail to get the audit trail without the proposal ID
+	_, err = sc.GetProposalAuditTrail(transactionContext, "")
+	require.EqualError(t, err, "the required parameter 'proposalID' is empty")
+}
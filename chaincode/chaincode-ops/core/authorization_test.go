@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newClientIdentity returns a client identity with the given ID, MSP roles (OUs) and certificate attributes.
+func newClientIdentity(id string, roles []string, attributes map[string]string) *mocks.ClientIdentity {
+	identity := &mocks.ClientIdentity{}
+	identity.GetIDReturns(id, nil)
+	identity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: roles}}, nil)
+	identity.GetAttributeValueStub = func(name string) (string, bool, error) {
+		value, found := attributes[name]
+		return value, found, nil
+	}
+	return identity
+}
+
+func TestSetAuthorizationRules(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+	rules := []AuthorizationRule{
+		{Function: "Vote", Attributes: []string{"opssc.role=voter"}},
+		{Function: "RequestProposal", Roles: []string{"admin"}},
+	}
+
+	// Case: Set the rules by the admin
+	transactionContext.GetClientIdentityReturns(newClientIdentity("admin-1", []string{"admin"}, nil))
+	config, err := sc.SetAuthorizationRules(transactionContext, rules)
+	require.NoError(t, err)
+	expected := &AuthorizationConfig{
+		ObjectType:  AuthorizationConfigObjectType,
+		MSPID:       "Org1MSP",
+		Rules:       rules,
+		UpdatedByID: "admin-1",
+		Time:        now.UTC().Format(time.RFC3339),
+	}
+	require.Equal(t, expected, config)
+	config, err = sc.GetAuthorizationConfig(transactionContext, "Org1MSP")
+	require.NoError(t, err)
+	require.Equal(t, expected, config)
+	config, err = sc.GetAuthorizationConfig(transactionContext, "Org2MSP")
+	require.NoError(t, err)
+	require.Nil(t, config)
+
+	// Case: Fail to set the rules by the identity other than the admin
+	transactionContext.GetClientIdentityReturns(newClientIdentity("client-1", []string{"client"}, map[string]string{"opssc.role": "voter"}))
+	_, err = sc.SetAuthorizationRules(transactionContext, nil)
+	require.ErrorIs(t, err, ErrUnauthorized)
+	require.EqualError(t, err, "the identity is not authorized to set the authorization rules: it should have the role admin")
+
+	// Case: Remove the rules by the admin
+	transactionContext.GetClientIdentityReturns(newClientIdentity("admin-1", []string{"admin"}, nil))
+	config, err = sc.SetAuthorizationRules(transactionContext, nil)
+	require.NoError(t, err)
+	require.Nil(t, config)
+	config, err = sc.GetAuthorizationConfig(transactionContext, "Org1MSP")
+	require.NoError(t, err)
+	require.Nil(t, config)
+
+	// Case: Fail with invalid rules
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "GetProposal", Roles: []string{"admin"}}})
+	require.EqualError(t, err, "the function 'GetProposal' is not supported by the authorization rules")
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "Vote", Roles: []string{"admin"}}, {Function: "Vote", Roles: []string{"client"}}})
+	require.EqualError(t, err, "the authorization rule for Vote is duplicated")
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "Vote"}})
+	require.EqualError(t, err, "the authorization rule for Vote should have at least one role or attribute")
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "Vote", Roles: []string{""}}})
+	require.EqualError(t, err, "the authorization rule for Vote should not contain an empty role")
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "Vote", Attributes: []string{"opssc.role"}}})
+	require.EqualError(t, err, "the attribute 'opssc.role' should be in the form of name=value")
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "Vote", Attributes: []string{"=voter"}}})
+	require.EqualError(t, err, "the attribute '=voter' should be in the form of name=value")
+	_, err = sc.GetAuthorizationConfig(transactionContext, "")
+	require.EqualError(t, err, "the required parameter 'mspID' is empty")
+}
+
+func TestAuthorize(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(time.Now()), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+	admin := newClientIdentity("org2-admin", []string{"admin"}, nil)
+	voter := newClientIdentity("org2-voter", []string{"client"}, map[string]string{"opssc.role": "voter"})
+	client := newClientIdentity("org2-client", []string{"client"}, map[string]string{"opssc.role": "agent"})
+
+	// Prepare: Org2 restricts the votes to the voters, and the proposals to the admins
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	transactionContext.GetClientIdentityReturns(admin)
+	_, err := sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{
+		{Function: "Vote", Roles: []string{"admin"}, Attributes: []string{"opssc.role=voter"}},
+		{Function: "RequestProposal", Roles: []string{"admin"}},
+	})
+	require.NoError(t, err)
+
+	// Case: The identities of the other orgs are not restricted, and the identity IDs are recorded
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	transactionContext.GetClientIdentityReturns(newClientIdentity("org1-client", []string{"client"}, nil))
+	_, input := baseProposalAndInput("")
+	proposal, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	require.Equal(t, "org1-client", proposal.CreatorID)
+	require.Equal(t, "org1-client", proposal.UpdatedByID)
+
+	// Case: Fail to vote by the identity without the role or the attribute
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	transactionContext.GetClientIdentityReturns(client)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.ErrorIs(t, err, ErrUnauthorized)
+	require.EqualError(t, err, "the identity is not authorized to call Vote: it should have any of the roles [admin] or the attributes [opssc.role=voter]")
+
+	// Case: Fail to request a proposal by the identity without the role
+	transactionContext.GetClientIdentityReturns(voter)
+	_, input = baseProposalAndInput("")
+	input.ID = "request-2"
+	input.ChaincodeName = "another"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the identity is not authorized to call RequestProposal: it should have any of the roles [admin]")
+
+	// Case: Vote by the identity with the attribute, and the identity ID is recorded in the history
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "request-1", TaskID: Vote, OrgID: "Org2MSP"})
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	for _, history := range histories {
+		require.Equal(t, "org2-voter", history.IdentityID)
+	}
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, "org1-client", proposal.CreatorID)
+	require.Equal(t, "org2-voter", proposal.UpdatedByID)
+
+	// Case: The function without any rule is not restricted
+	transactionContext.GetClientIdentityReturns(client)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"}))
+}
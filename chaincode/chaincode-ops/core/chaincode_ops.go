@@ -9,7 +9,6 @@ package core
 import (
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"os"
 	"strconv"
 	"time"
@@ -27,16 +26,25 @@ type SmartContract struct {
 }
 
 // ChaincodePackage represents information on chaincode package of a proposed update.
+// The source of the package is one of the variants specified by SourceType, and only the fields for the variant can be set.
 type ChaincodePackage struct {
-	Repository        string `json:"repository"`
-	CommitID          string `json:"commitID"`
+	SourceType        string `json:"sourceType,omitempty" metadata:",optional"` // GitSource (if empty), OCIImageSource or ArchiveSource
+	Repository        string `json:"repository" metadata:",optional"`           // (git) the repository path (e.g., github.com/project_name/repository_name)
+	CommitID          string `json:"commitID" metadata:",optional"`             // (git) the commit ID, branch or tag
 	PathToSourceFiles string `json:"pathToSourceFiles,omitempty" metadata:",optional"`
+	ImageName         string `json:"imageName,omitempty" metadata:",optional"`   // (ociImage) the image name without the tag (e.g., ghcr.io/project_name/image_name)
+	ImageDigest       string `json:"imageDigest,omitempty" metadata:",optional"` // (ociImage) the image digest (e.g., sha256:<hex>)
+	ArchiveURL        string `json:"archiveURL,omitempty" metadata:",optional"`  // (archive) the URL to download the chaincode package (.tar.gz)
+	ArchiveHash       string `json:"archiveHash,omitempty" metadata:",optional"` // (archive) the SHA-256 hash of the chaincode package in hex
 	Type              string `json:"type"`
 }
 
 // ChaincodeDefinition represents information on chaincode definition of a proposed update.
 type ChaincodeDefinition struct {
 	Sequence            int64  `json:"sequence"`
+	Version             string `json:"version,omitempty" metadata:",optional"`           // the sequence is used if this is not set
+	EndorsementPlugin   string `json:"endorsementPlugin,omitempty" metadata:",optional"` // DefaultEndorsementPlugin is used if this is not set
+	ValidationPlugin    string `json:"validationPlugin,omitempty" metadata:",optional"`  // DefaultValidationPlugin is used if this is not set
 	InitRequired        bool   `json:"initRequired"`
 	ValidationParameter string `json:"validationParameter"`
 	Collections         string `json:"collections,omitempty" metadata:",optional"`
@@ -53,6 +61,18 @@ type ChaincodeUpdateProposal struct {
 	ChaincodeDefinition ChaincodeDefinition `json:"chaincodeDefinition"`
 	Status              string              `json:"status"`
 	Time                string              `json:"time"`
+	VotingDeadline      string              `json:"votingDeadline,omitempty" metadata:",optional"`
+	NotBefore           string              `json:"notBefore,omitempty" metadata:",optional"`        // the start of the deployment window (RFC3339)
+	NotAfter            string              `json:"notAfter,omitempty" metadata:",optional"`         // the end of the deployment window (RFC3339)
+	FailedTask          string              `json:"failedTask,omitempty" metadata:",optional"`       // the task which caused the failure (only for failed proposals)
+	RollbackOf          string              `json:"rollbackOf,omitempty" metadata:",optional"`       // the ID of the proposal restored by this proposal (only for rollback proposals)
+	OperationTargets    []string            `json:"operationTargets,omitempty" metadata:",optional"` // the orgs designated to commit the chaincode definition (set when the proposal is acknowledged)
+	ReleaseID           string              `json:"releaseID,omitempty" metadata:",optional"`        // the ID of the release proposal which bundles this proposal (only for members of a release)
+	DependsOn           []string            `json:"dependsOn,omitempty" metadata:",optional"`        // the IDs of the proposals which should be committed before the deployment (prefixed with "channel-ops:" for channel update proposals)
+	UpdatedBy           string              `json:"updatedBy,omitempty" metadata:",optional"`        // the MSP ID of the org which submitted the last update of the proposal (set when the proposal is put to stateDB)
+	CreatorID           string              `json:"creatorID,omitempty" metadata:",optional"`        // the identity ID of the creator
+	UpdatedByID         string              `json:"updatedByID,omitempty" metadata:",optional"`      // the identity ID of the caller which submitted the last update of the proposal (set when the proposal is put to stateDB)
+	RetriedAt           string              `json:"retriedAt,omitempty" metadata:",optional"`        // the time when the failed proposal is retried last (RFC3339)
 }
 
 // ChaincodeUpdateProposalInput represents a request input of a new chaincode update proposal.
@@ -62,6 +82,11 @@ type ChaincodeUpdateProposalInput struct {
 	ChaincodeName       string              `json:"chaincodeName"`
 	ChaincodePackage    ChaincodePackage    `json:"chaincodePackage"`
 	ChaincodeDefinition ChaincodeDefinition `json:"chaincodeDefinition"`
+	VotingDeadline      string              `json:"votingDeadline,omitempty" metadata:",optional"` // RFC3339
+	NotBefore           string              `json:"notBefore,omitempty" metadata:",optional"`      // RFC3339 (the deployment is held until this time)
+	NotAfter            string              `json:"notAfter,omitempty" metadata:",optional"`       // RFC3339 (the deployment is not started after this time)
+	ChannelIDs          []string            `json:"channelIDs,omitempty" metadata:",optional"`     // the channels to deploy the chaincode (only for RequestMultiChannelProposal, instead of ChannelID)
+	DependsOn           []string            `json:"dependsOn,omitempty" metadata:",optional"`      // the IDs of the proposals which should be committed before the deployment (prefixed with "channel-ops:" for channel update proposals)
 }
 
 // History describes a history of each task (e.g., vote, chaincode commit), and which is stored as a state in the ledger.
@@ -73,6 +98,9 @@ type History struct {
 	Status     string `json:"status"`
 	Data       string `json:"data"`
 	Time       string `json:"time"`
+	PackageID  string `json:"packageID,omitempty" metadata:",optional"`  // the package ID computed by the org (only for acknowledge)
+	Delegate   string `json:"delegate,omitempty" metadata:",optional"`   // the MSP ID of the org which voted on behalf of OrgID (only for delegated vote)
+	IdentityID string `json:"identityID,omitempty" metadata:",optional"` // the identity ID of the caller which executed the task
 }
 
 // TaskStatusUpdateRequest represents a request input for updating a task status of a proposal.
@@ -80,6 +108,8 @@ type TaskStatusUpdateRequest struct {
 	ProposalID string `json:"proposalID"`
 	Status     string `json:"status,omitempty" metadata:",optional"`
 	Data       string `json:"data,omitempty" metadata:",optional"`
+	PackageID  string `json:"packageID,omitempty" metadata:",optional"`  // the package ID computed by the org (only for acknowledge)
+	OnBehalfOf string `json:"onBehalfOf,omitempty" metadata:",optional"` // the MSP ID of the org which delegates the vote to the caller (only for vote)
 }
 
 // HistoryQueryParams represents query parameters for getting histories from the ledger.
@@ -91,14 +121,29 @@ type HistoryQueryParams struct {
 
 // DeploymentEventDetail represents details of DeploymentEvent.
 type DeploymentEventDetail struct {
-	Proposal         ChaincodeUpdateProposal `json:"proposal"`
-	OperationTargets []string                `json:"operationTargets"`
+	Proposal            ChaincodeUpdateProposal `json:"proposal"`
+	OperationTargets    []string                `json:"operationTargets"`
+	NotAcknowledgedOrgs []string                `json:"notAcknowledgedOrgs,omitempty"` // the orgs which have not acknowledged the deployment yet (only for deployEvent)
+}
+
+// FailureEventDetail represents details of FailedEvent.
+type FailureEventDetail struct {
+	Proposal   ChaincodeUpdateProposal `json:"proposal"`
+	TaskID     string                  `json:"taskID"`
+	FailedOrgs map[string]string       `json:"failedOrgs"` // MSP ID -> the data reported with the failure
 }
 
 // VotingConfig represents voting config.
 type VotingConfig struct {
-	ObjectType       string `json:"docType"` //docType is used to distinguish the various types of objects in state database
-	MaxMaliciousOrgs int    `json:"maxMaliciousOrgs"`
+	ObjectType       string         `json:"docType"`                                  //docType is used to distinguish the various types of objects in state database
+	ChannelID        string         `json:"channelID,omitempty" metadata:",optional"` // the channel to which the config applies (empty for the default config)
+	MaxMaliciousOrgs int            `json:"maxMaliciousOrgs"`
+	OrgWeights       map[string]int `json:"orgWeights,omitempty" metadata:",optional"`   // MSP ID -> weight in votes (1 for orgs not listed)
+	RequiredOrgs     []string       `json:"requiredOrgs,omitempty" metadata:",optional"` // orgs whose approval is mandatory
+	Threshold        float64        `json:"threshold,omitempty" metadata:",optional"`    // fraction of the total weight required for approval (if set, this is used instead of MaxMaliciousOrgs)
+
+	AcknowledgeCriteria string `json:"acknowledgeCriteria,omitempty" metadata:",optional"` // criteria for acknowledging the deployment (AckByAll is used if this is not set)
+	AcknowledgeCount    int    `json:"acknowledgeCount,omitempty" metadata:",optional"`    // number of orgs required for acknowledging the deployment (only for AckByCount)
 }
 
 // Object types
@@ -108,6 +153,9 @@ const (
 	VotingConfigObjectType = "votingConfig"
 )
 
+// VotingConfigBootstrappedKey is the key of the flag which records that the voting config is bootstrapped.
+const VotingConfigBootstrappedKey = "votingConfigBootstrapped"
+
 // Chaincode event names
 const (
 	NewProposalEvent     = "newProposalEvent"
@@ -117,6 +165,12 @@ const (
 	CommittedEvent       = "committedEvent"
 	RejectedEvent        = "rejectedEvent"
 	WithdrawnEvent       = "withdrawnEvent"
+	ExpiredEvent         = "expiredEvent"
+	FailedEvent          = "failedEvent"
+	PackageMismatchEvent = "packageMismatchEvent"
+	ScheduledEvent       = "scheduledEvent"
+	ReleasedEvent        = "releasedEvent"
+	WaitingEvent         = "waitingEvent"
 )
 
 // Task IDs
@@ -133,8 +187,11 @@ const (
 	Rejected     = "rejected"
 	Acknowledged = "acknowledged"
 	Committed    = "committed"
-	Failed       = "failed" // Unsupported
+	Failed       = "failed"
 	Withdrawn    = "withdrawn"
+	Expired      = "expired"
+	Scheduled    = "scheduled"
+	Waiting      = "waiting" // approved, but waiting for the dependencies to be committed
 )
 
 // Status for Vote Tasks
@@ -151,8 +208,17 @@ const (
 
 // Criteria for moving the next task
 const (
-	ALL      = "all"
-	MAJORITY = "majority"
+	ALL            = "all"
+	MAJORITY       = "majority"
+	SimpleMajority = "simpleMajority" // majority regardless of the voting config
+)
+
+// Criteria for acknowledging the deployment (AcknowledgeCriteria in the voting config)
+const (
+	AckByAll          = "all"          // all orgs in the channel
+	AckByMajority     = "majority"     // a majority of orgs in the channel
+	AckByCount        = "count"        // AcknowledgeCount orgs in the channel
+	AckByAgreedVoters = "agreedVoters" // all orgs which agreed to the proposal
 )
 
 // Const for channel-ops
@@ -166,6 +232,18 @@ var (
 	ErrProposalNotFound = fmt.Errorf("proposal not found")
 	// ErrProposalIDAreadyInUse is returned when the requested proposal ID is already in use.
 	ErrProposalIDAreadyInUse = fmt.Errorf("proposalID already in use")
+	// ErrProposalExpired is returned when the requested proposal is already expired.
+	ErrProposalExpired = fmt.Errorf("the proposal is expired")
+	// ErrProposalNotFailed is returned when retrying the proposal which is not failed.
+	ErrProposalNotFailed = fmt.Errorf("only the failed proposal can be retried")
+	// ErrProposalNotAcknowledged is returned when reporting the commit result of the proposal which is not acknowledged.
+	ErrProposalNotAcknowledged = fmt.Errorf("only the commit result of the acknowledged proposal can be reported")
+	// ErrNotOperationTarget is returned when the commit result is reported by the org which is not designated to commit the chaincode.
+	ErrNotOperationTarget = fmt.Errorf("only the operation targets of the deployment can report the commit result")
+	// ErrNotAllowedToRetry is returned when the failed commit is retried by the org which is neither the operation target nor the proposer.
+	ErrNotAllowedToRetry = fmt.Errorf("only the operation targets of the deployment or the proposer can retry the failed commit")
+	// ErrReleaseMember is returned when voting for or withdrawing the proposal which is a member of a release proposal.
+	ErrReleaseMember = fmt.Errorf("the proposal is a member of a release (vote for or withdraw the release instead)")
 )
 
 // RequestProposal requests a new chaincode update proposal.
@@ -181,18 +259,76 @@ var (
 //   (if the request can be approved without any other votes)
 //   name: PrepareToCommitEvent(<proposalID>)
 //   payload: DeploymentEventDetail
+//   (if the request can be approved without any other votes, but any dependency is not committed yet)
+//   name: WaitingEvent(<proposalID>)
+//   payload: the waiting proposal
+//   (if the request can be approved without any other votes, but the deployment window is not open)
+//   name: ScheduledEvent(<proposalID>)
+//   payload: the scheduled proposal
 //   (else)
 //   name: newProposalEvent(<proposalID>)
 //   payload: the created proposal
 //
 func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterface, input ChaincodeUpdateProposalInput) (*ChaincodeUpdateProposal, error) {
+	if err := s.authorize(ctx, "RequestProposal"); err != nil {
+		return nil, err
+	}
+	return s.requestProposal(ctx, input, "")
+}
+
+// requestProposal requests a new chaincode update proposal.
+// If rollbackOf is not empty, the proposal is linked to the proposal with the ID as a rollback proposal.
+func (s *SmartContract) requestProposal(ctx contractapi.TransactionContextInterface, input ChaincodeUpdateProposalInput, rollbackOf string) (*ChaincodeUpdateProposal, error) {
+
+	proposal, err := s.newProposal(ctx, input, rollbackOf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Put the proposal to stateDB
+	if err = s.putProposal(ctx, proposal); err != nil {
+		return nil, fmt.Errorf("failed to put the proposal: %v", err)
+	}
+
+	// Vote for myself
+	history, err := s.putHistory(ctx, proposal.ID, Vote, Agreed, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put the history that the org votes for: %v", err)
+	}
+
+	// If the vote from this organization alone meets the MAJORITY condition,
+	// Update proposal status to "Approved" (or "Waiting" for the dependencies, or "Scheduled" outside the deployment window) and issue the event (the event is set in the internal function)
+	votePassed, err := s.meetCriteria(ctx, *history, MAJORITY, false, proposal.ChannelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do meetCriteria: %v", err)
+	}
+	if votePassed {
+		if err = s.updateStatusOnApproval(ctx, *proposal); err != nil {
+			return nil, fmt.Errorf("failed to update the status: %v", err)
+		}
+		return proposal, nil
+	}
+
+	// Else issue NewProposalEvent
+	if err = s.setEvent(ctx, NewProposalEvent, proposal.ID, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// newProposal validates the input and builds a new chaincode update proposal without putting it to stateDB.
+// This also fails if the chaincode cannot be claimed by the proposal.
+func (s *SmartContract) newProposal(ctx contractapi.TransactionContextInterface, input ChaincodeUpdateProposalInput, rollbackOf string) (*ChaincodeUpdateProposal, error) {
 
 	// Validate input
-	// TODO: stricter input check may be desirable here although a wrong proposal is rejected when _lifecycle chaincode is executed.
 	if input.ID == "" {
 		return nil, fmt.Errorf("the required parameter proposal 'ID' is empty")
 	}
 
+	if len(input.ChannelIDs) > 0 {
+		return nil, fmt.Errorf("the parameter 'ChannelIDs' is only available for RequestMultiChannelProposal")
+	}
+
 	if input.ChannelID == "" {
 		return nil, fmt.Errorf("the required parameter 'ChannelID' is empty")
 	}
@@ -209,17 +345,24 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 		return nil, fmt.Errorf("the required parameter 'ChaincodeDefinition.ValidationParameter' is empty")
 	}
 
-	url, err := url.Parse(input.ChaincodePackage.Repository)
-	if err != nil || url.Scheme != "" {
-		return nil, fmt.Errorf("the parameter 'ChaincodePackage.Repository' should be repository path (e.g., github.com/project_name/repository_name)")
+	setChaincodeDefinitionDefaults(&input.ChaincodeDefinition)
+
+	if err := validateChaincodeDefinition(input.ChaincodeDefinition); err != nil {
+		return nil, err
+	}
+
+	if err := validateChaincodePackage(input.ChaincodePackage); err != nil {
+		return nil, err
 	}
 
-	if input.ChaincodePackage.Type == "" {
-		return nil, fmt.Errorf("the required parameter 'ChaincodePackage.Type' is empty")
+	if input.VotingDeadline != "" {
+		if _, err := time.Parse(time.RFC3339, input.VotingDeadline); err != nil {
+			return nil, fmt.Errorf("the parameter 'VotingDeadline' should be RFC3339 format: %v", err)
+		}
 	}
 
-	if input.ChaincodePackage.CommitID == "" {
-		return nil, fmt.Errorf("the required parameter 'ChaincodePackage.CommitID' is empty")
+	if err := validateDeploymentWindow(input.NotBefore, input.NotAfter); err != nil {
+		return nil, err
 	}
 
 	// Build the proposal
@@ -228,21 +371,40 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
 	}
 
+	identityID, err := getIdentityID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	txTimestamp, err := getTxTimestampRFC3339(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
 	}
 
+	if input.VotingDeadline != "" && isOverdue(input.VotingDeadline, txTimestamp) {
+		return nil, fmt.Errorf("the parameter 'VotingDeadline' should be later than the current time")
+	}
+
+	if input.NotAfter != "" && isOverdue(input.NotAfter, txTimestamp) {
+		return nil, fmt.Errorf("the parameter 'NotAfter' should be later than the current time")
+	}
+
 	proposal := ChaincodeUpdateProposal{
 		ObjectType:          ProposalObjectType,
 		ID:                  input.ID,
 		Creator:             mspID,
+		CreatorID:           identityID,
 		Time:                txTimestamp,
 		Status:              Proposed,
 		ChannelID:           input.ChannelID,
 		ChaincodeName:       input.ChaincodeName,
 		ChaincodePackage:    input.ChaincodePackage,
 		ChaincodeDefinition: input.ChaincodeDefinition,
+		VotingDeadline:      input.VotingDeadline,
+		NotBefore:           input.NotBefore,
+		NotAfter:            input.NotAfter,
+		RollbackOf:          rollbackOf,
+		DependsOn:           input.DependsOn,
 	}
 
 	// Check whether the proposal is acceptable to the target channel
@@ -250,42 +412,19 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 		return nil, fmt.Errorf("proposal is not accepted by the channel. The proposal should be made to the 'application' or 'ops' channel: %v", err)
 	}
 
-	// Fail if the proposal with the ID already exists
-	if p, _ := s.GetProposal(ctx, input.ID); p != nil {
+	// Fail if the proposal with the ID already exists (or is archived)
+	if p, _ := s.getProposalOrArchived(ctx, input.ID); p != nil {
 		return nil, ErrProposalIDAreadyInUse
 	}
 
-	// Put the proposal to stateDB
-	if err = s.putProposal(ctx, proposal); err != nil {
-		return nil, fmt.Errorf("failed to put the proposal: %v", err)
-	}
-
-	// Vote for myself
-	history, err := s.putHistory(ctx, proposal.ID, Vote, Agreed, "", false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to put the history that the org votes for: %v", err)
-	}
-
-	// If the vote from this organization alone meets the MAJORITY condition,
-	// Update proposal status to "Approved" and issue PrepareToCommitEvent (the event is set in the internal function)
-	votePassed, err := s.meetCriteria(ctx, *history, MAJORITY, false, proposal.ChannelID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to do meetCriteria: %v", err)
-	}
-	if votePassed {
-		if err = s.updateStatusToApproved(ctx, proposal); err != nil {
-			return nil, fmt.Errorf("failed to update the status: %v", err)
-		}
-		return &proposal, nil
+	// Fail if any dependency does not exist
+	if err = s.validateDependencies(ctx, input.ID, input.DependsOn); err != nil {
+		return nil, err
 	}
 
-	// Else issue NewProposalEvent
-	proposalJSON, err := json.Marshal(proposal)
-	if err != nil {
-		return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
-	}
-	if err = ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", NewProposalEvent, proposal.ID), []byte(proposalJSON)); err != nil {
-		return nil, fmt.Errorf("error happened emitting event: %v", err)
+	// Fail if the sequence is stale or another proposal for the chaincode is open
+	if err = s.claimChaincodeState(ctx, proposal); err != nil {
+		return nil, err
 	}
 	return &proposal, nil
 }
@@ -293,6 +432,7 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 // Vote votes for / against the chaincode update proposal.
 // This function records the vote as a state into the ledger.
 // Also, if the proposal is voted by MAJORITY, this changes the status of the proposal from proposed to approved.
+// If OnBehalfOf is set, this records the vote as the vote of the given organization which delegates its votes to the caller.
 //
 // Arguments:
 //   0: taskStatusUpdateRequest - the request input for voting for/against the chaincode update proposal
@@ -304,12 +444,22 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 //   (if the status is changed to approved)
 //   name: PrepareToCommitEvent(<proposalID>)
 //   payload: DeploymentEventDetail
+//   (if the status is changed to waiting)
+//   name: WaitingEvent(<proposalID>)
+//   payload: the waiting proposal
+//   (if the status is changed to scheduled)
+//   name: ScheduledEvent(<proposalID>)
+//   payload: the scheduled proposal
 //   (else)
 //   name: NewVoteEvent(<proposalID>)
 //   payload: nil
 //
 func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
 
+	if err := s.authorize(ctx, "Vote"); err != nil {
+		return err
+	}
+
 	// Set default values
 	if taskStatusUpdateRequest.Status == "" {
 		taskStatusUpdateRequest.Status = Agreed
@@ -329,65 +479,44 @@ func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, taskSt
 	if err != nil {
 		return fmt.Errorf("failed to get the proposal: %v", err)
 	}
+	// If the proposal is expired (or its voting deadline has passed), return error
+	expired, err := s.isExpired(ctx, *proposal)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return ErrProposalExpired
+	}
 	// If the proposal status already got changed from "Proposed", return error
 	if proposal.Status != Proposed {
 		return fmt.Errorf("the voting is already closed")
 	}
+	// If the proposal is a member of a release, the votes should be made for the release
+	if proposal.ReleaseID != "" {
+		return ErrReleaseMember
+	}
 
 	// Put the task status as a history to stateDB
-	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, Vote, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, false)
+	// (The delegated vote is put as the vote of the delegator)
+	history, err := s.newHistory(ctx, taskStatusUpdateRequest.ProposalID, Vote, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data)
 	if err != nil {
 		return fmt.Errorf("failed to put the history: %v", err)
 	}
-
-	// [State Transition]
-	// Prerequisite Proposal Status: "Proposed"
-	//
-	// Conditions:
-	//   - Case A: (1) voting status is "Agreed" AND (2) voted by MAJORITY
-	//         -> Update proposal status to "Approved" and issue PrepareToCommitEvent (the event is set in the internal function)
-	//
-	//   - Case B: (1) voting status is "Disagreed" AND (2) the number of "Agreed" can not satisfy MAJORITY
-	//         -> Update proposal status to "Rejected" and issue RejectedEvent (the event is set in the internal function)
-	//
-	//   - Case C: Others
-	//         -> Not update proposal status and issue NewVoteEvent
-	switch taskStatusUpdateRequest.Status {
-	// Case A:
-	case Agreed:
-		votePassed, err := s.meetCriteria(ctx, *history, MAJORITY, false, proposal.ChannelID)
-		if err != nil {
-			return fmt.Errorf("failed to do meetCriteria: %v", err)
-		}
-		if votePassed {
-			if err = s.updateStatusToApproved(ctx, *proposal); err != nil {
-				return fmt.Errorf("failed to update the status: %v", err)
-			}
-			return nil
-		}
-	// Case B:
-	case Disagreed:
-		voteRejected, err := s.meetCriteria(ctx, *history, MAJORITY, true, proposal.ChannelID)
-		if err != nil {
-			return fmt.Errorf("failed to do meetCriteria: %v", err)
-		}
-		if voteRejected {
-			if err = s.updateStatusToRejected(ctx, *proposal); err != nil {
-				return fmt.Errorf("failed to update the status: %v", err)
-			}
-			return nil
+	if taskStatusUpdateRequest.OnBehalfOf != "" {
+		if err := s.delegateHistory(ctx, *proposal, history, taskStatusUpdateRequest.OnBehalfOf); err != nil {
+			return err
 		}
 	}
-	// Case C:
-	if err := ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", NewVoteEvent, proposal.ID), []byte(nil)); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
+	if err := s.storeHistory(ctx, *history, false); err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
 	}
-	return nil
+
+	return s.evaluateVotes(ctx, *proposal, *history)
 }
 
 // WithdrawProposal withdraws the chaincode update proposal.
 // This only accepts the request from the proposing organization.
-// This function is only available before the decision of the proposal.
+// This function is only available before the decision of the proposal or while the proposal is scheduled or waiting.
 //
 // Arguments:
 //   0: proposalID - the ID for the chaincode update proposal
@@ -401,6 +530,10 @@ func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, taskSt
 //
 func (s *SmartContract) WithdrawProposal(ctx contractapi.TransactionContextInterface, proposalID string) error {
 
+	if err := s.authorize(ctx, "WithdrawProposal"); err != nil {
+		return err
+	}
+
 	// Validate input
 	if proposalID == "" {
 		return fmt.Errorf("the required parameter 'proposalID' is empty")
@@ -412,11 +545,25 @@ func (s *SmartContract) WithdrawProposal(ctx contractapi.TransactionContextInter
 		return ErrProposalNotFound
 	}
 
-	// If the proposal status already got changed from "Proposed", return error
-	if proposal.Status != Proposed {
+	// If the proposal is expired (or its voting deadline has passed), return error
+	expired, err := s.isExpired(ctx, *proposal)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return ErrProposalExpired
+	}
+
+	// If the proposal status already got changed from "Proposed" (or "Scheduled" or "Waiting"), return error
+	if proposal.Status != Proposed && proposal.Status != Scheduled && proposal.Status != Waiting {
 		return fmt.Errorf("the voting is already closed")
 	}
 
+	// If the proposal is a member of a release, the release should be withdrawn instead
+	if proposal.ReleaseID != "" {
+		return ErrReleaseMember
+	}
+
 	// If the proposal is not created by the requester, return error
 	mspID, err := s.getMSPID(ctx)
 	if err != nil {
@@ -435,7 +582,12 @@ func (s *SmartContract) WithdrawProposal(ctx contractapi.TransactionContextInter
 
 // Acknowledge records the task status executed by agents for preparing the deployment based on the chaincode update proposal.
 // This function records the result of the task as a state into the ledger.
-// Also, if the proposal is acknowledged by ALL organizations, this changes the status of the proposal from approved to acknowledged.
+// Also, if the proposal meets the acknowledge criteria in the voting config for the channel (ALL organizations by default),
+// this changes the status of the proposal from approved to acknowledged.
+// If the proposal is a member of a release, the deployment is not started until all the members of the release are acknowledged.
+// If any organization reports a failure, this changes the status of the proposal from approved to failed.
+// The package IDs computed by the organizations are compared, and if they differ, the status is kept approved
+// until the divergent organizations acknowledge again with the same package.
 //
 // Arguments:
 //   0: taskStatusUpdateRequest - the task status executed by agents for preparing the deployment based on the chaincode update proposal
@@ -447,9 +599,22 @@ func (s *SmartContract) WithdrawProposal(ctx contractapi.TransactionContextInter
 //   (if the status is changed to acknowledged)
 //   name: DeployEvent(<proposalID>)
 //   payload: DeploymentEventDetail
+//   (if the status is changed to acknowledged and all the other members of the release which bundles the proposal are acknowledged)
+//   name: ReleaseDeployEvent(<releaseID>)
+//   payload: ReleaseDeploymentEventDetail
+//   (if the status is changed to failed)
+//   name: FailedEvent(<proposalID>)
+//   payload: FailureEventDetail
+//   (if the package IDs computed by the organizations differ)
+//   name: PackageMismatchEvent(<proposalID>)
+//   payload: PackageMismatchEventDetail
 //
 func (s *SmartContract) Acknowledge(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
 
+	if err := s.authorize(ctx, "Acknowledge"); err != nil {
+		return err
+	}
+
 	// Set default status
 	if taskStatusUpdateRequest.Status == "" {
 		taskStatusUpdateRequest.Status = Success
@@ -467,25 +632,50 @@ func (s *SmartContract) Acknowledge(ctx contractapi.TransactionContextInterface,
 	if err != nil {
 		return fmt.Errorf("failed to get the proposal: %v", err)
 	}
+	// If the proposal is expired, return error
+	if proposal.Status == Expired {
+		return ErrProposalExpired
+	}
 	// If the proposal status already got changed from "Approved", return success immediately
 	if proposal.Status != Approved {
 		return nil
 	}
 
-	// Put the task status as a history to stateDB
-	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, Acknowledge, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, true)
+	// Put the task status with the package ID computed by the org as a history to stateDB
+	history, err := s.newHistory(ctx, taskStatusUpdateRequest.ProposalID, Acknowledge, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data)
 	if err != nil {
 		return fmt.Errorf("failed to put the history: %v", err)
 	}
+	history.PackageID = taskStatusUpdateRequest.PackageID
+	if err = s.storeHistory(ctx, *history, true); err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
+	}
+
+	// If the task is failed, update proposal status to "Failed" and issue FailedEvent (the event is internally set)
+	if taskStatusUpdateRequest.Status == Failure {
+		if err = s.updateStatusToFailed(ctx, *proposal, *history); err != nil {
+			return fmt.Errorf("failed to update the status: %v", err)
+		}
+		return nil
+	}
 
-	// If (1) the proposal status remains "Approved" and (2) the proposal is acknowledged by ALL orgs,
+	// If the package IDs computed by the orgs differ, keep the proposal status "Approved" and issue PackageMismatchEvent (the event is internally set)
+	mismatched, err := s.detectPackageMismatch(ctx, *proposal, *history)
+	if err != nil {
+		return fmt.Errorf("failed to compare the package IDs: %v", err)
+	}
+	if mismatched {
+		return nil
+	}
+
+	// If (1) the proposal status remains "Approved" and (2) the proposal meets the acknowledge criteria (ALL orgs by default),
 	// then update proposal status to "Acknowledged" and issue commitEvent (the event is internally set)
-	isAcknowledgedByAllOrgs, err := s.meetCriteria(ctx, *history, ALL, false, proposal.ChannelID)
+	isAcknowledged, notAcknowledgedOrgs, err := s.meetAcknowledgeCriteria(ctx, *proposal, *history)
 	if err != nil {
-		return fmt.Errorf("failed to do meetCriteria: %v", err)
+		return fmt.Errorf("failed to do meetAcknowledgeCriteria: %v", err)
 	}
-	if taskStatusUpdateRequest.Status == Success && isAcknowledgedByAllOrgs {
-		if err = s.updateStatusToAcknowledged(ctx, *proposal); err != nil {
+	if taskStatusUpdateRequest.Status == Success && isAcknowledged {
+		if err = s.updateStatusToAcknowledged(ctx, *proposal, notAcknowledgedOrgs); err != nil {
 			return fmt.Errorf("failed to update the status: %v", err)
 		}
 	}
@@ -494,7 +684,10 @@ func (s *SmartContract) Acknowledge(ctx contractapi.TransactionContextInterface,
 
 // NotifyCommitResult records the task status executed by agents for commiting the deployment based on the chaincode update proposal.
 // This function records the result of the task as a state into the ledger.
-// Also, if the proposal is acknowledged by ALL organizations, this changes the status of the proposal from acknowledged to committed.
+// Also, if the commit succeeds, this changes the status of the proposal from acknowledged to committed.
+// If the commit fails, this changes the status of the proposal from acknowledged to failed.
+// Both results are accepted only for the acknowledged proposal and only from the orgs designated to commit the chaincode
+// (or from the orgs which acknowledged the proposal if no org is designated, i.e., for the proposals acknowledged before the designation is introduced).
 //
 // Arguments:
 //   0: taskStatusUpdateRequest - the task status executed by agents for commiting the deployment based on the chaincode update proposal
@@ -503,12 +696,19 @@ func (s *SmartContract) Acknowledge(ctx contractapi.TransactionContextInterface,
 //   0: error
 //
 // Events:
-//   (if the status is changed to acknowledged)
+//   (if the status is changed to committed)
 //   name: CommittedEvent(<proposalID>)
 //   payload: nil
+//   (if the status is changed to failed)
+//   name: FailedEvent(<proposalID>)
+//   payload: FailureEventDetail
 //
 func (s *SmartContract) NotifyCommitResult(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
 
+	if err := s.authorize(ctx, "NotifyCommitResult"); err != nil {
+		return err
+	}
+
 	// Set default status
 	if taskStatusUpdateRequest.Status == "" {
 		taskStatusUpdateRequest.Status = Success
@@ -527,19 +727,40 @@ func (s *SmartContract) NotifyCommitResult(ctx contractapi.TransactionContextInt
 		return fmt.Errorf("failed to get the proposal: %v", err)
 	}
 
-	// TODO: Before the history registration, this function may need strict condition checking (transaction creator and/or whether to meet criteria)
-	_, err = s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, Commit, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, true)
+	// Refuse the commit result (either success or failure) of the proposal which is not acknowledged
+	if proposal.Status != Acknowledged {
+		return ErrProposalNotAcknowledged
+	}
+
+	// Accept the report only from the orgs designated to commit the chaincode in the deployEvent
+	mspID, err := s.getMSPID(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to put the history: %v", err)
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	if len(proposal.OperationTargets) > 0 && !contains(proposal.OperationTargets, mspID) {
+		return ErrNotOperationTarget
+	}
+	if len(proposal.OperationTargets) == 0 {
+		// The proposals acknowledged before the operation targets are recorded accept the report from any org which acknowledged them
+		acknowledgedOrgs, err := s.getOrgsWithTaskStatus(ctx, proposal.ID, Acknowledge, Success)
+		if err != nil {
+			return fmt.Errorf("failed to get the acknowledged orgs: %v", err)
+		}
+		if !acknowledgedOrgs[mspID] {
+			return ErrNotOperationTarget
+		}
 	}
 
-	// If the commit task status is not success, return immediately
-	if taskStatusUpdateRequest.Status != Success {
-		return nil
+	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, Commit, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, true)
+	if err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
 	}
 
-	// If the proposal status already got changed from "Acknowledged", return success immediately
-	if proposal.Status != Acknowledged {
+	// If the commit task status is not success, update proposal status to "Failed" and issue FailedEvent
+	if taskStatusUpdateRequest.Status != Success {
+		if err = s.updateStatusToFailed(ctx, *proposal, *history); err != nil {
+			return fmt.Errorf("failed to update the status: %v", err)
+		}
 		return nil
 	}
 
@@ -550,6 +771,87 @@ func (s *SmartContract) NotifyCommitResult(ctx contractapi.TransactionContextInt
 	return nil
 }
 
+// RetryProposal retries the failed stage of the chaincode update proposal without creating a new proposal.
+// If the proposal failed in the acknowledge task, this changes the status of the proposal from failed to approved.
+// If the proposal failed in the commit task, this changes the status of the proposal from failed to acknowledged
+// and the organizations designated to commit the chaincode are kept.
+// The failed commit can be retried only by the designated organizations or the proposer.
+//
+// Arguments:
+//   0: proposalID - the ID for the chaincode update proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   (if the proposal failed in the acknowledge task)
+//   name: PrepareToDeployEvent(<proposalID>)
+//   payload: DeploymentEventDetail
+//   (if the proposal failed in the commit task)
+//   name: DeployEvent(<proposalID>)
+//   payload: DeploymentEventDetail
+//
+func (s *SmartContract) RetryProposal(ctx contractapi.TransactionContextInterface, proposalID string) error {
+
+	if err := s.authorize(ctx, "RetryProposal"); err != nil {
+		return err
+	}
+
+	// Validate input
+	if proposalID == "" {
+		return fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	// Get proposal from StateDB
+	proposal, err := s.GetProposal(ctx, proposalID)
+	if err != nil {
+		return fmt.Errorf("failed to get the proposal: %v", err)
+	}
+	if proposal.Status != Failed {
+		return ErrProposalNotFailed
+	}
+
+	// The failed commit can be retried only by the designated committers or the proposer
+	if proposal.FailedTask == Commit {
+		mspID, err := s.getMSPID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get MSP ID: %v", err)
+		}
+		if mspID != proposal.Creator && !contains(proposal.OperationTargets, mspID) {
+			return ErrNotAllowedToRetry
+		}
+	}
+
+	// Fail if another proposal for the chaincode has been made after the failure
+	if err = s.claimChaincodeState(ctx, *proposal); err != nil {
+		return err
+	}
+
+	// Record the time of the retry to ignore the failures reported in the previous attempts
+	if proposal.RetriedAt, err = getTxTimestampRFC3339(ctx); err != nil {
+		return fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	failedTask := proposal.FailedTask
+	proposal.FailedTask = ""
+	switch failedTask {
+	case Acknowledge:
+		err = s.updateStatusToApproved(ctx, *proposal)
+	case Commit:
+		var notAcknowledgedOrgs []string
+		if _, notAcknowledgedOrgs, err = s.getNotAcknowledgedOrgs(ctx, *proposal, ""); err != nil {
+			return fmt.Errorf("failed to get the orgs which have not acknowledged: %v", err)
+		}
+		err = s.updateStatusToAcknowledged(ctx, *proposal, notAcknowledgedOrgs)
+	default:
+		return fmt.Errorf("unknown failed task: %v", failedTask)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update the status: %v", err)
+	}
+	return nil
+}
+
 // GetAllProposals returns the all chaincode update proposals.
 //
 // Arguments: none
@@ -576,87 +878,143 @@ func (s *SmartContract) GetAllProposals(ctx contractapi.TransactionContextInterf
 		if err = json.Unmarshal(proposalJSON.Value, proposal); err != nil {
 			return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
 		}
+		setChaincodeDefinitionDefaults(&proposal.ChaincodeDefinition)
 		proposals[proposalJSON.Key] = proposal
 	}
 	return proposals, nil
 }
 
-// SetMaxMaliciousOrgsInVotes sets number of max malicious orgs in votes.
+// ExpireProposals changes the status of the proposals whose voting deadline has passed from proposed to expired.
+// Whether the deadline has passed is judged by the timestamp of the transaction.
 //
-// Arguments:
-//   0: number - number of max malicious orgs in votes
+// Arguments: none
 //
 // Returns:
-//   0: error
+//   0: the list of the IDs of the expired proposals
+//   1: error
 //
-func (s *SmartContract) SetMaxMaliciousOrgsInVotes(ctx contractapi.TransactionContextInterface, number int) error {
+// Events:
+//   (if one or more proposals are expired)
+//   name: expiredEvent
+//   payload: the list of the expired proposals (ordered by the proposal ID)
+//   (NOTE: a transaction can only have one chaincode event, so the event does not have the proposal ID suffix,
+//    and it includes each expired proposal in the same shape as the payload of the events for a proposal)
+//
+func (s *SmartContract) ExpireProposals(ctx contractapi.TransactionContextInterface) ([]string, error) {
 
-	// Validate arguments
-	if number < 0 {
-		return fmt.Errorf("number of max malicious orgs in votes should be greater than 0")
+	if err := s.authorize(ctx, "ExpireProposals"); err != nil {
+		return nil, err
 	}
 
-	// struct to JSON
-	votingConfigJSON, err := json.Marshal(VotingConfig{
-		ObjectType:       VotingConfigObjectType,
-		MaxMaliciousOrgs: number,
-	})
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
 	if err != nil {
-		return fmt.Errorf("error happened marshalling the voting config: %v", err)
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
 	}
 
-	// Put votingConfig to StateDB
-	err = ctx.GetStub().PutState(VotingConfigObjectType, votingConfigJSON)
+	proposals, err := s.getProposalsByStatus(ctx, Proposed)
 	if err != nil {
-		return fmt.Errorf("error happened persisting the voting config on the ledger: %v", err)
+		return nil, fmt.Errorf("failed to get proposals: %v", err)
 	}
 
-	return nil
+	expiredIDs := []string{}
+	expiredProposals := []*ChaincodeUpdateProposal{}
+	for _, proposal := range proposals {
+		if proposal.VotingDeadline == "" || !isOverdue(proposal.VotingDeadline, txTimestamp) {
+			continue
+		}
+		proposal.Status = Expired
+		if err := s.putProposal(ctx, proposal); err != nil {
+			return nil, fmt.Errorf("failed to update the status: %v", err)
+		}
+		expiredIDs = append(expiredIDs, proposal.ID)
+		expiredProposals = append(expiredProposals, proposal)
+	}
+	if len(expiredIDs) == 0 {
+		return expiredIDs, nil
+	}
+
+	if err = s.setEvent(ctx, ExpiredEvent, "", expiredProposals); err != nil {
+		return nil, err
+	}
+	return expiredIDs, nil
 }
 
-// UnsetMaxMaliciousOrgsInVotes unsets number of max malicious orgs in votes.
+// SetMaxMaliciousOrgsInVotes sets number of max malicious orgs in votes to the default voting config.
+// This is only available for bootstrapping (i.e., before the voting config is set by this func or a governance proposal).
+// After that, the voting config can be changed only through a governance proposal, even if the default voting config is unset.
 //
-// Arguments: None
+// Arguments:
+//   0: number - number of max malicious orgs in votes
 //
 // Returns:
 //   0: error
 //
-func (s *SmartContract) UnsetMaxMaliciousOrgsInVotes(ctx contractapi.TransactionContextInterface) error {
+func (s *SmartContract) SetMaxMaliciousOrgsInVotes(ctx contractapi.TransactionContextInterface, number int) error {
 
-	// Delete votingConfig to StateDB
-	err := ctx.GetStub().DelState(VotingConfigObjectType)
+	if err := s.authorize(ctx, "SetMaxMaliciousOrgsInVotes"); err != nil {
+		return err
+	}
+
+	// Validate arguments
+	if number < 0 {
+		return fmt.Errorf("number of max malicious orgs in votes should be greater than 0")
+	}
+
+	// Fail if the voting config is already bootstrapped
+	// (the default voting config set before the bootstrapped flag is introduced is also regarded as bootstrapped)
+	bootstrapped, err := s.isVotingConfigBootstrapped(ctx)
 	if err != nil {
-		return fmt.Errorf("error happened delete the voting config from the ledger: %v", err)
+		return err
+	}
+	votingConfig, err := s.GetVotingConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if bootstrapped || votingConfig != nil {
+		return ErrVotingConfigAlreadySet
 	}
 
-	return nil
+	if err := s.putVotingConfig(ctx, VotingConfig{
+		ObjectType:       VotingConfigObjectType,
+		MaxMaliciousOrgs: number,
+	}); err != nil {
+		return err
+	}
+	return s.markVotingConfigBootstrapped(ctx)
 }
 
-// GetVotingConfig returns the voting config.
+// GetVotingConfig returns the default voting config.
 //
-// Arguments: None
+// Arguments: none
 //
 // Returns:
-//   0: the voting config (if voting config is not set, the func returns null)
+//   0: the default voting config (if the default voting config is not set, the func returns null)
 //   1: error
 //
 func (s *SmartContract) GetVotingConfig(ctx contractapi.TransactionContextInterface) (*VotingConfig, error) {
+	return s.getVotingConfig(ctx, "")
+}
 
-	votingConfigJSON, err := ctx.GetStub().GetState(VotingConfigObjectType)
-	if err != nil {
-		return nil, fmt.Errorf("error happened reading voting config: %v", err)
-	}
-
-	if votingConfigJSON == nil {
-		return nil, nil
-	}
+// GetEffectiveVotingConfig returns the voting config which is effective for the given channel.
+// If the voting config for the channel is not set, the default voting config is returned instead.
+// The channel ID of the returned config tells which config is effective (empty for the default config).
+//
+// Arguments:
+//   0: channelID - the channel ID (if this is empty, the func returns the default voting config)
+//
+// Returns:
+//   0: the effective voting config (if no voting config is set, the func returns null)
+//   1: error
+//
+func (s *SmartContract) GetEffectiveVotingConfig(ctx contractapi.TransactionContextInterface, channelID string) (*VotingConfig, error) {
 
-	var votingConfig VotingConfig
-	err = json.Unmarshal(votingConfigJSON, &votingConfig)
-	if err != nil {
-		return nil, fmt.Errorf("error happened unmarshalling a voting config JSON representation to struct: %v", err)
+	if channelID != "" {
+		votingConfig, err := s.getVotingConfig(ctx, channelID)
+		if err != nil || votingConfig != nil {
+			return votingConfig, err
+		}
 	}
-	return &votingConfig, nil
+	return s.getVotingConfig(ctx, "")
 }
 
 func buildAttributesForGetHistories(params HistoryQueryParams) []string {
@@ -740,6 +1098,7 @@ func (s *SmartContract) GetProposal(ctx contractapi.TransactionContextInterface,
 	if err != nil {
 		return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
 	}
+	setChaincodeDefinitionDefaults(&proposal.ChaincodeDefinition)
 	return &proposal, nil
 }
 
@@ -747,6 +1106,12 @@ func (s *SmartContract) GetProposal(ctx contractapi.TransactionContextInterface,
 
 // Function to check whether to meet criteria for the proposal state transitions
 func (s *SmartContract) meetCriteria(ctx contractapi.TransactionContextInterface, currentHistory History, criteria string, checkUnachivable bool, targetChannel string) (bool, error) {
+	return s.meetCriteriaWithVoters(ctx, currentHistory, criteria, checkUnachivable, targetChannel, false)
+}
+
+// meetCriteriaWithVoters is the same as meetCriteria except that,
+// if channelMembersOnly is true, only the votes from the orgs in the target channel are counted.
+func (s *SmartContract) meetCriteriaWithVoters(ctx contractapi.TransactionContextInterface, currentHistory History, criteria string, checkUnachivable bool, targetChannel string, channelMembersOnly bool) (bool, error) {
 	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(HistoryObjectType, []string{currentHistory.ProposalID, currentHistory.TaskID})
 	if err != nil {
 		return false, fmt.Errorf("error happened reading keys from ledger: %v", err)
@@ -765,11 +1130,14 @@ func (s *SmartContract) meetCriteria(ctx contractapi.TransactionContextInterface
 	}
 
 	criteriaNum := totalOrgNum
+	var votingConfig *VotingConfig
 	switch criteria {
+	case SimpleMajority:
+		criteriaNum = criteriaNum/2 + 1
 	case MAJORITY:
 		criteriaNum = criteriaNum/2 + 1
 
-		votingConfig, err := s.GetVotingConfig(ctx)
+		votingConfig, err = s.GetEffectiveVotingConfig(ctx, targetChannel)
 		if err != nil {
 			return false, err
 		}
@@ -803,135 +1171,258 @@ func (s *SmartContract) meetCriteria(ctx contractapi.TransactionContextInterface
 		}
 	}
 
+	if channelMembersOnly {
+		channelOrgs, err := s.getOrganizationsInChannel(ctx, targetChannel)
+		if err != nil {
+			return false, err
+		}
+		for mspID := range orgs {
+			if !contains(channelOrgs, mspID) {
+				delete(orgs, mspID)
+			}
+		}
+	}
+
+	if votingConfig != nil && votingConfig.hasVotingRules() {
+		return s.meetVotingRules(ctx, *votingConfig, orgs, len(orgs) >= criteriaNum, checkUnachivable, targetChannel)
+	}
+
 	if len(orgs) >= criteriaNum {
 		return true, nil
 	}
 	return false, nil
 }
 
+// evaluateVotes changes the status of the proposal based on the votes including the given vote.
+func (s *SmartContract) evaluateVotes(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, history History) error {
+	// [State Transition]
+	// Prerequisite Proposal Status: "Proposed"
+	//
+	// Conditions:
+	//   - Case A: (1) voting status is "Agreed" AND (2) voted by MAJORITY
+	//         -> Update proposal status to "Approved" and issue PrepareToCommitEvent (the event is set in the internal function)
+	//            (or update proposal status to "Waiting" and issue WaitingEvent if any dependency of the proposal is not committed yet,
+	//             or update proposal status to "Scheduled" and issue ScheduledEvent outside the deployment window of the proposal)
+	//
+	//   - Case B: (1) voting status is "Disagreed" AND (2) the number of "Agreed" can not satisfy MAJORITY
+	//         -> Update proposal status to "Rejected" and issue RejectedEvent (the event is set in the internal function)
+	//
+	//   - Case C: Others
+	//         -> Not update proposal status and issue NewVoteEvent
+	switch history.Status {
+	// Case A:
+	case Agreed:
+		votePassed, err := s.meetCriteria(ctx, history, MAJORITY, false, proposal.ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if votePassed {
+			if err = s.updateStatusOnApproval(ctx, proposal); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return nil
+		}
+	// Case B:
+	case Disagreed:
+		voteRejected, err := s.meetCriteria(ctx, history, MAJORITY, true, proposal.ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if voteRejected {
+			if err = s.updateStatusToRejected(ctx, proposal); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return nil
+		}
+	}
+	// Case C:
+	return s.setEvent(ctx, NewVoteEvent, proposal.ID, nil)
+}
+
 // Functions to manage proposal status
-func (s *SmartContract) updateStatusToAcknowledged(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+func (s *SmartContract) updateStatusToAcknowledged(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, notAcknowledgedOrgs []string) error {
+	// Set this org as a chaincode committer
+	// (unless the committers are already designated, i.e., when the failed commit is retried)
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+
 	proposal.Status = Acknowledged
+	if len(proposal.OperationTargets) == 0 {
+		proposal.OperationTargets = []string{mspID}
+	}
 
 	// Put proposal to stateDB
-	if err := s.putProposal(ctx, proposal); err != nil {
+	if err := s.putProposal(ctx, &proposal); err != nil {
 		return err
 	}
 
-	// Issue CommitEvent
-	// Set this org as a chaincode committer
-	mspID, err := s.getMSPID(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get MSP ID: %v", err)
+	// The members of a release are deployed together after all of them are acknowledged
+	if proposal.ReleaseID != "" {
+		return s.deployReleaseIfAcknowledged(ctx, proposal, notAcknowledgedOrgs)
 	}
 
+	// Issue CommitEvent
 	// Create deployment event detail
 	eventDetail := DeploymentEventDetail{
-		OperationTargets: []string{mspID},
-		Proposal:         proposal,
+		OperationTargets:    proposal.OperationTargets,
+		Proposal:            proposal,
+		NotAcknowledgedOrgs: notAcknowledgedOrgs,
 	}
 
-	// struct to JSON
-	eventDetailJSON, err := json.Marshal(eventDetail)
+	// Set Event
+	return s.setEvent(ctx, DeployEvent, proposal.ID, eventDetail)
+}
+
+func (s *SmartContract) updateStatusToApproved(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	eventDetail, err := s.approveProposal(ctx, proposal)
 	if err != nil {
 		return err
 	}
 
-	// Set Event
-	if err = ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", DeployEvent, proposal.ID), eventDetailJSON); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
-	}
-	return nil
+	// -- Set Event
+	return s.setEvent(ctx, PrepareToDeployEvent, proposal.ID, eventDetail)
 }
 
-func (s *SmartContract) updateStatusToApproved(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+// approveProposal changes the status of the proposal to approved,
+// and returns the deployment event detail to be notified to the agents.
+func (s *SmartContract) approveProposal(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) (*DeploymentEventDetail, error) {
 	proposal.Status = Approved
 
 	// Put proposal to stateDB
-	if err := s.putProposal(ctx, proposal); err != nil {
-		return err
+	if err := s.putProposal(ctx, &proposal); err != nil {
+		return nil, err
+	}
+	if _, err := s.syncReleaseStatus(ctx, proposal); err != nil {
+		return nil, err
 	}
 
-	// Issue PrepareToCommitEvent
+	// Create deployment event detail
 
 	// -- Get organization list from channel-ops
 	channelOpsArgs := util.ToChaincodeArgs("GetOrganizationsInChannel", proposal.ChannelID)
 	response := ctx.GetStub().InvokeChaincode(channelOpsCCName(), channelOpsArgs, "")
 	if response.Status != shim.OK {
-		return fmt.Errorf("error happened querying " + channelOpsCCName() + ":" + response.Message)
+		return nil, fmt.Errorf("error happened querying " + channelOpsCCName() + ":" + response.Message)
 	}
 	oList := []string{}
 	if err := json.Unmarshal(response.Payload, &oList); err != nil {
-		return err
+		return nil, err
 	}
 
-	// -- Create deployment event detail
-	eventDetail := DeploymentEventDetail{
+	return &DeploymentEventDetail{
 		OperationTargets: oList,
 		Proposal:         proposal,
-	}
-
-	// -- struct to JSON
-	eventDetailJSON, err := json.Marshal(eventDetail)
-	if err != nil {
-		return err
-	}
-
-	// -- Set Event
-	if err = ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", PrepareToDeployEvent, proposal.ID), eventDetailJSON); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
-	}
-	return nil
+	}, nil
 }
 
 func (s *SmartContract) updateStatusToRejected(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
 	proposal.Status = Rejected
 
 	// Put proposal to stateDB
-	if err := s.putProposal(ctx, proposal); err != nil {
+	if err := s.putProposal(ctx, &proposal); err != nil {
 		return err
 	}
 
 	// -- Set Event
-	if err := ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", RejectedEvent, proposal.ID), nil); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
-	}
-	return nil
+	return s.setEvent(ctx, RejectedEvent, proposal.ID, nil)
 }
 
 func (s *SmartContract) updateStatusToWithdrawn(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
 	proposal.Status = Withdrawn
 
 	// Put proposal to stateDB
-	if err := s.putProposal(ctx, proposal); err != nil {
+	if err := s.putProposal(ctx, &proposal); err != nil {
 		return err
 	}
 
 	// -- Set Event
-	if err := ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", WithdrawnEvent, proposal.ID), nil); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
-	}
-	return nil
+	return s.setEvent(ctx, WithdrawnEvent, proposal.ID, nil)
 }
 
 func (s *SmartContract) updateStatusToCommitted(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
 	proposal.Status = Committed
 
 	// Put proposal to stateDB
-	if err := s.putProposal(ctx, proposal); err != nil {
+	if err := s.putProposal(ctx, &proposal); err != nil {
+		return err
+	}
+
+	// Record the committed sequence
+	if err := s.releaseChaincodeState(ctx, proposal); err != nil {
+		return err
+	}
+	if _, err := s.syncReleaseStatus(ctx, proposal); err != nil {
 		return err
 	}
 
 	// -- Set Event
-	if err := ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", CommittedEvent, proposal.ID), []byte(nil)); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
+	return s.setEvent(ctx, CommittedEvent, proposal.ID, nil)
+}
+
+func (s *SmartContract) updateStatusToFailed(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, currentHistory History) error {
+	proposal.Status = Failed
+	proposal.FailedTask = currentHistory.TaskID
+
+	// Put proposal to stateDB
+	if err := s.putProposal(ctx, &proposal); err != nil {
+		return err
+	}
+	if _, err := s.syncReleaseStatus(ctx, proposal); err != nil {
+		return err
 	}
 
-	return nil
+	// -- Collect the failing organizations and their data
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(HistoryObjectType, []string{currentHistory.ProposalID, currentHistory.TaskID})
+	if err != nil {
+		return fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	failedOrgs := map[string]string{}
+	failedOrgs[currentHistory.OrgID] = currentHistory.Data
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return fmt.Errorf("error happened iterating over available histories: %v", err)
+		}
+		var resultHistory History
+		if err = json.Unmarshal(result.Value, &resultHistory); err != nil {
+			return fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+		}
+		// Ignore the failures reported before the last retry
+		if proposal.RetriedAt != "" && !isOverdue(proposal.RetriedAt, resultHistory.Time) {
+			continue
+		}
+		if resultHistory.Status == Failure && resultHistory.OrgID != currentHistory.OrgID {
+			failedOrgs[resultHistory.OrgID] = resultHistory.Data
+		}
+	}
+
+	// -- Create failure event detail
+	eventDetail := FailureEventDetail{
+		Proposal:   proposal,
+		TaskID:     currentHistory.TaskID,
+		FailedOrgs: failedOrgs,
+	}
+
+	// -- Set Event
+	return s.setEvent(ctx, FailedEvent, proposal.ID, eventDetail)
 }
 
 // Accessors to StateDB
-func (s *SmartContract) putProposal(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+func (s *SmartContract) putProposal(ctx contractapi.TransactionContextInterface, proposal *ChaincodeUpdateProposal) error {
+	// Record the submitter of the update, which is kept in the key history for the audit trail
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	proposal.UpdatedBy = mspID
+	if proposal.UpdatedByID, err = getIdentityID(ctx); err != nil {
+		return err
+	}
+
 	// Create composite key
 	compositeKey, err := ctx.GetStub().CreateCompositeKey(ProposalObjectType, []string{proposal.ID})
 	if err != nil {
@@ -953,7 +1444,104 @@ func (s *SmartContract) putProposal(ctx contractapi.TransactionContextInterface,
 	return nil
 }
 
+func (s *SmartContract) putVotingConfig(ctx contractapi.TransactionContextInterface, votingConfig VotingConfig) error {
+	// struct to JSON
+	votingConfigJSON, err := json.Marshal(votingConfig)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the voting config: %v", err)
+	}
+
+	// Put votingConfig to StateDB
+	key, err := s.createKeyForVotingConfig(ctx, votingConfig.ChannelID)
+	if err != nil {
+		return err
+	}
+	err = ctx.GetStub().PutState(key, votingConfigJSON)
+	if err != nil {
+		return fmt.Errorf("error happened persisting the voting config on the ledger: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SmartContract) delVotingConfig(ctx contractapi.TransactionContextInterface, channelID string) error {
+	key, err := s.createKeyForVotingConfig(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().DelState(key); err != nil {
+		return fmt.Errorf("error happened delete the voting config from the ledger: %v", err)
+	}
+	return nil
+}
+
+// markVotingConfigBootstrapped records that the voting config is bootstrapped.
+// The flag is never cleared, so that SetMaxMaliciousOrgsInVotes is not reopened by unsetting the default voting config.
+func (s *SmartContract) markVotingConfigBootstrapped(ctx contractapi.TransactionContextInterface) error {
+	if err := ctx.GetStub().PutState(VotingConfigBootstrappedKey, []byte("true")); err != nil {
+		return fmt.Errorf("error happened persisting the bootstrapped flag of the voting config on the ledger: %v", err)
+	}
+	return nil
+}
+
+// isVotingConfigBootstrapped returns whether the voting config is bootstrapped.
+func (s *SmartContract) isVotingConfigBootstrapped(ctx contractapi.TransactionContextInterface) (bool, error) {
+	flag, err := ctx.GetStub().GetState(VotingConfigBootstrappedKey)
+	if err != nil {
+		return false, fmt.Errorf("error happened reading the bootstrapped flag of the voting config: %v", err)
+	}
+	return flag != nil, nil
+}
+
+// getVotingConfig returns the voting config stored for the given channel (or the default voting config if channelID is empty).
+func (s *SmartContract) getVotingConfig(ctx contractapi.TransactionContextInterface, channelID string) (*VotingConfig, error) {
+	key, err := s.createKeyForVotingConfig(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	votingConfigJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading voting config: %v", err)
+	}
+
+	if votingConfigJSON == nil {
+		return nil, nil
+	}
+
+	var votingConfig VotingConfig
+	err = json.Unmarshal(votingConfigJSON, &votingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling a voting config JSON representation to struct: %v", err)
+	}
+	return &votingConfig, nil
+}
+
+// createKeyForVotingConfig returns the key for the voting config.
+// The default voting config is stored with the plain key for backward compatibility.
+func (s *SmartContract) createKeyForVotingConfig(ctx contractapi.TransactionContextInterface, channelID string) (string, error) {
+	if channelID == "" {
+		return VotingConfigObjectType, nil
+	}
+	key, err := ctx.GetStub().CreateCompositeKey(VotingConfigObjectType, []string{channelID})
+	if err != nil {
+		return "", fmt.Errorf("error happened creating composite key for voting config: %v", err)
+	}
+	return key, nil
+}
+
 func (s *SmartContract) putHistory(ctx contractapi.TransactionContextInterface, proposalID string, taskID string, status string, data string, overwritable bool) (*History, error) {
+	history, err := s.newHistory(ctx, proposalID, taskID, status, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storeHistory(ctx, *history, overwritable); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// newHistory creates the history of the task executed by the organization in the current transaction.
+func (s *SmartContract) newHistory(ctx contractapi.TransactionContextInterface, proposalID string, taskID string, status string, data string) (*History, error) {
 
 	// Validate input
 	if proposalID == "" {
@@ -965,6 +1553,11 @@ func (s *SmartContract) putHistory(ctx contractapi.TransactionContextInterface,
 		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
 	}
 
+	identityID, err := getIdentityID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	txTimestamp, err := getTxTimestampRFC3339(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
@@ -979,38 +1572,59 @@ func (s *SmartContract) putHistory(ctx contractapi.TransactionContextInterface,
 		Status:     status,
 		Data:       data,
 		Time:       txTimestamp,
+		IdentityID: identityID,
 	}
+	return history, nil
+}
+
+// storeHistory puts the history to the ledger.
+func (s *SmartContract) storeHistory(ctx contractapi.TransactionContextInterface, history History, overwritable bool) error {
 
 	// struct to JSON
 	historyJSON, err := json.Marshal(history)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Create composite key
 	compositeKey, err := ctx.GetStub().CreateCompositeKey(HistoryObjectType, []string{history.ProposalID, history.TaskID, history.OrgID})
 	if err != nil {
-		return nil, fmt.Errorf("error happened creating composite key for history: %v", err)
+		return fmt.Errorf("error happened creating composite key for history: %v", err)
 	}
 
 	// Check whether there is the state on the state DB
 	if !overwritable {
 		obtainedJSON, err := ctx.GetStub().GetState(compositeKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read from world state: %v", err)
+			return fmt.Errorf("failed to read from world state: %v", err)
 		}
 		if obtainedJSON != nil {
-			return nil, fmt.Errorf("the state is already exists: %v", history.OrgID)
+			return fmt.Errorf("the state is already exists: %v", history.OrgID)
 		}
 	}
 
 	// Put state
 	err = ctx.GetStub().PutState(compositeKey, historyJSON)
 	if err != nil {
-		return nil, fmt.Errorf("error happened marshalling the history: %v", err)
+		return fmt.Errorf("error happened marshalling the history: %v", err)
 	}
 
-	return history, nil
+	return nil
+}
+
+// isExpired returns true when the proposal is expired or the voting deadline of the proposal has passed.
+func (s *SmartContract) isExpired(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) (bool, error) {
+	if proposal.Status == Expired {
+		return true, nil
+	}
+	if proposal.Status != Proposed || proposal.VotingDeadline == "" {
+		return false, nil
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+	return isOverdue(proposal.VotingDeadline, txTimestamp), nil
 }
 
 func (s *SmartContract) getMSPID(ctx contractapi.TransactionContextInterface) (string, error) {
@@ -1050,7 +1664,30 @@ func getTxTimestampRFC3339(ctx contractapi.TransactionContextInterface) (string,
 		return "", err
 	}
 	tm := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	return tm.Format(time.RFC3339), nil
+	return tm.UTC().Format(time.RFC3339), nil
+}
+
+// isOverdue returns true when the given RFC3339 deadline is not later than the given RFC3339 time.
+func isOverdue(deadline string, now string) bool {
+	deadlineTime, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return false
+	}
+	nowTime, err := time.Parse(time.RFC3339, now)
+	if err != nil {
+		return false
+	}
+	return !deadlineTime.After(nowTime)
+}
+
+// contains returns whether the list contains the given value.
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 func channelOpsCCName() string {
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package core
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -16,12 +18,15 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/events"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -54,6 +59,16 @@ type stateQueryIterator interface {
 	shim.StateQueryIteratorInterface
 }
 
+//go:generate counterfeiter -o mocks/historyqueryiterator.go -fake-name HistoryQueryIterator . historyQueryIterator
+type historyQueryIterator interface {
+	shim.HistoryQueryIteratorInterface
+}
+
+//go:generate counterfeiter -o mocks/clientidentity.go -fake-name ClientIdentity . clientIdentity
+type clientIdentity interface {
+	cid.ClientIdentity
+}
+
 // Dummy implementation to create compose key
 func createComposeKey(objectType string, keys []string) (string, error) {
 	return objectType + "_" + strings.Join(keys, "_"), nil
@@ -87,10 +102,157 @@ func invokeChaincode(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
 	panic("Unexpected func name")
 }
 
+// worldState is a dummy in-memory world state which is wired into the fake chaincode stub.
+// This is used by test cases that go through multiple transactions.
+type worldState map[string][]byte
+
+func newWorldState(chaincodeStub *mocks.ChaincodeStub) worldState {
+	ws := worldState{}
+	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return ws[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		ws[key] = value
+		return nil
+	}
+	chaincodeStub.DelStateStub = func(key string) error {
+		delete(ws, key)
+		return nil
+	}
+	chaincodeStub.GetStateByPartialCompositeKeyStub = func(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+		prefix, _ := createComposeKey(objectType, keys)
+		return ws.iterator(prefix), nil
+	}
+	chaincodeStub.GetStateByPartialCompositeKeyWithPaginationStub = func(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+		prefix, _ := createComposeKey(objectType, keys)
+		iterator, fetched, nextBookmark := ws.page(prefix, pageSize, bookmark)
+		return iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: fetched, Bookmark: nextBookmark}, nil
+	}
+	chaincodeStub.GetQueryResultStub = func(query string) (shim.StateQueryIteratorInterface, error) {
+		return ws.selectorIterator(query)
+	}
+	return ws
+}
+
+// selectorIterator returns an iterator over the states which match all of the field values in the selector of the rich query.
+// Only the conditions with values are supported (i.e., operators are not supported).
+func (ws worldState) selectorIterator(query string) (*mocks.StateQueryIterator, error) {
+	var richQuery struct {
+		Selector map[string]interface{} `json:"selector"`
+	}
+	if err := json.Unmarshal([]byte(query), &richQuery); err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for key := range ws {
+		state := map[string]interface{}{}
+		if err := json.Unmarshal(ws[key], &state); err != nil {
+			continue
+		}
+		matched := true
+		for field, value := range richQuery.Selector {
+			matched = matched && state[field] == value
+		}
+		if matched {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return ws.keysIterator(keys), nil
+}
+
+// iterator returns an iterator over the states whose keys match the given dummy partial composite key in key order.
+func (ws worldState) iterator(prefix string) *mocks.StateQueryIterator {
+	return ws.keysIterator(ws.keys(prefix))
+}
+
+// keys returns the sorted keys which match the given dummy partial composite key.
+func (ws worldState) keys(prefix string) []string {
+	keys := []string{}
+	for key := range ws {
+		if key == prefix || strings.HasPrefix(key, strings.TrimSuffix(prefix, "_")+"_") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// page returns an iterator over at most pageSize states which start from the bookmark
+// with the number of the fetched states and the bookmark for the next page.
+func (ws worldState) page(prefix string, pageSize int32, bookmark string) (*mocks.StateQueryIterator, int32, string) {
+	keys := []string{}
+	nextBookmark := ""
+	for _, key := range ws.keys(prefix) {
+		if key < bookmark {
+			continue
+		}
+		if int32(len(keys)) == pageSize {
+			nextBookmark = key
+			break
+		}
+		keys = append(keys, key)
+	}
+	return ws.keysIterator(keys), int32(len(keys)), nextBookmark
+}
+
+func (ws worldState) keysIterator(keys []string) *mocks.StateQueryIterator {
+	iterator := &mocks.StateQueryIterator{}
+	index := 0
+	iterator.HasNextStub = func() bool {
+		return index < len(keys)
+	}
+	iterator.NextStub = func() (*queryresult.KV, error) {
+		kv := &queryresult.KV{Key: keys[index], Value: ws[keys[index]]}
+		index++
+		return kv, nil
+	}
+	return iterator
+}
+
+// lastEvent returns the name and the data (unwrapped from the envelope) of the event set most recently.
+func lastEvent(chaincodeStub *mocks.ChaincodeStub) (string, []byte) {
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(chaincodeStub.SetEventCallCount() - 1)
+	return eventName, eventData(eventPayload)
+}
+
+// eventData returns the data in the envelope of the given event payload (nil if the event has no data).
+func eventData(eventPayload []byte) []byte {
+	envelope, err := events.Decode(eventPayload)
+	if err != nil {
+		panic(err)
+	}
+	if len(envelope.Data) == 0 {
+		return nil
+	}
+	return envelope.Data
+}
+
+// deployProposal drives the given proposal from the request to the commit by Org1MSP and Org2MSP.
+func deployProposal(t *testing.T, sc *SmartContract, transactionContext *mocks.TransactionContext, chaincodeStub *mocks.ChaincodeStub, input ChaincodeUpdateProposalInput) {
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: input.ID}))
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: input.ID}))
+
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: input.ID}))
+	require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: input.ID}))
+
+	proposal, err := sc.GetProposal(transactionContext, input.ID)
+	require.NoError(t, err)
+	require.Equal(t, Committed, proposal.Status)
+}
+
 func TestRequestProposal(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -101,20 +263,26 @@ func TestRequestProposal(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 	expectedProposal, input := baseProposalAndInput(formattedTS)
 
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	chaincodeStub.GetQueryResultReturns(&mocks.StateQueryIterator{}, nil)
 
 	actual, err := sc.RequestProposal(transactionContext, input)
 	require.NoError(t, err)
 	actualJSON, err := json.Marshal(actual)
 	require.NoError(t, err)
 	key, state := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, "chaincodeState_mychannel_basic", key)
+	require.JSONEq(t, `{"docType": "chaincodeState", "channelID": "mychannel", "chaincodeName": "basic", "lastCommittedSequence": 0, "inFlightProposal": "request-1"}`, string(state))
+
+	key, state = chaincodeStub.PutStateArgsForCall(1)
 	require.Equal(t, "proposal_request-1", key)
 
+	expectedProposal.UpdatedBy = "Org1MSP"
 	expectedJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(expectedJSON), string(state))
@@ -122,9 +290,9 @@ func TestRequestProposal(t *testing.T) {
 
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "newProposalEvent.request-1", eventName)
-	require.Equal(t, string(expectedJSON), string(eventPayload))
+	require.Equal(t, string(expectedJSON), string(eventData(eventPayload)))
 
-	key, state = chaincodeStub.PutStateArgsForCall(1)
+	key, state = chaincodeStub.PutStateArgsForCall(2)
 	require.Equal(t, "history_request-1_vote_Org1MSP", key)
 	expectedHistory := History{
 		ObjectType: HistoryObjectType,
@@ -141,6 +309,7 @@ func TestRequestProposal(t *testing.T) {
 	// Case: the proposal can be approved without any other votes
 	expectedProposal, input = baseProposalAndInput(formattedTS)
 	input.ID = "request-2"
+	input.ChaincodeName = "basic2"
 	config := VotingConfig{
 		ObjectType:       VotingConfigObjectType,
 		MaxMaliciousOrgs: 0,
@@ -150,13 +319,15 @@ func TestRequestProposal(t *testing.T) {
 	chaincodeStub.GetStateReturns(configJSON, nil)
 
 	getStateCount := chaincodeStub.GetStateCallCount()
-	chaincodeStub.GetStateReturnsOnCall(getStateCount, nil, nil)          // GetProposal
-	chaincodeStub.GetStateReturnsOnCall(getStateCount+1, nil, nil)        // GetHistory
-	chaincodeStub.GetStateReturnsOnCall(getStateCount+2, configJSON, nil) // GetVotingConfig
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+1, nil, nil)        // GetProposal
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+2, nil, nil)        // GetArchivedProposal
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+3, nil, nil)        // getChaincodeState
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+4, nil, nil)        // GetHistory
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+5, configJSON, nil) // GetVotingConfig
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.NoError(t, err)
 
-	key, state = chaincodeStub.PutStateArgsForCall(3)
+	key, state = chaincodeStub.PutStateArgsForCall(5)
 	require.Equal(t, "history_request-2_vote_Org1MSP", key)
 	expectedHistory.ProposalID = input.ID
 	expectedJSON, err = json.Marshal(expectedHistory)
@@ -164,10 +335,12 @@ func TestRequestProposal(t *testing.T) {
 	require.JSONEq(t, string(expectedJSON), string(state))
 
 	expectedProposal.ID = input.ID
+	expectedProposal.ChaincodeName = input.ChaincodeName
 	expectedProposal.Status = Approved
+	expectedProposal.UpdatedBy = "Org1MSP"
 	expectedJSON, err = json.Marshal(expectedProposal)
 	require.NoError(t, err)
-	key, state = chaincodeStub.PutStateArgsForCall(4)
+	key, state = chaincodeStub.PutStateArgsForCall(6)
 	require.Equal(t, "proposal_request-2", key)
 	require.JSONEq(t, string(expectedJSON), string(state))
 
@@ -201,6 +374,13 @@ func TestRequestProposal(t *testing.T) {
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.EqualError(t, err, "the required parameter 'ChaincodeDefinition.ValidationParameter' is empty")
 
+	// Case: Fail to request when the ChaincodeDefinition.ValidationParameter is malformed
+	_, input = baseProposalAndInput(formattedTS)
+	input.ChaincodeDefinition.ValidationParameter = base64.StdEncoding.EncodeToString([]byte("OR('Org1MSP.member'"))
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the parameter 'ChaincodeDefinition.ValidationParameter' is invalid: invalid signature policy expression")
+
 	// Case: Fail to request when the ChaincodePackage.Repository is invalid
 	_, input = baseProposalAndInput(formattedTS)
 	input.ChaincodePackage.Repository = "https://github.com/hyperledger/fabric-samples"
@@ -257,20 +437,28 @@ func TestRequestProposal(t *testing.T) {
 
 	// Case: Fail to request when putHistory occurs an error
 	cc := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(cc+2, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(cc+6, "", fmt.Errorf("failed to create composite key"))
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.EqualError(t, err, "failed to put the history that the org votes for: error happened creating composite key for history: failed to create composite key")
 
 	// Case: Fail to request when putProposal occurs an error
-	chaincodeStub.CreateCompositeKeyReturns("", fmt.Errorf("failed to create composite key"))
+	cc = chaincodeStub.CreateCompositeKeyCallCount()
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(cc+5, "", fmt.Errorf("failed to create composite key"))
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.EqualError(t, err, "failed to put the proposal: error happened creating composite key for proposal: failed to create composite key")
+
+	// Case: Fail to request when getting the chaincode state occurs an error
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(chaincodeStub.CreateCompositeKeyCallCount(), AuthorizationConfigObjectType, nil)
+	chaincodeStub.CreateCompositeKeyReturns("", fmt.Errorf("failed to create composite key"))
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "error happened creating composite key for chaincode state: failed to create composite key")
 }
 
 func TestVote(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -284,12 +472,12 @@ func TestVote(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
@@ -312,11 +500,11 @@ func TestVote(t *testing.T) {
 
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "newVoteEvent.request-1", eventName)
-	require.Equal(t, []byte(nil), eventPayload)
+	require.Equal(t, []byte(nil), eventData(eventPayload))
 
 	// Case: Vote for the proposal and the votes pass the majority
 	getStateCount := chaincodeStub.GetStateCallCount()
-	chaincodeStub.GetStateReturnsOnCall(getStateCount, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+1, baseProposalJSON, nil)
 	historyOrg1 := History{
 		ObjectType: HistoryObjectType,
 		ProposalID: "request-1",
@@ -343,6 +531,7 @@ func TestVote(t *testing.T) {
 
 	key, state = chaincodeStub.PutStateArgsForCall(2)
 	baseProposal.Status = Approved
+	baseProposal.UpdatedBy = "Org2MSP"
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(baseProposalJSON), string(state))
@@ -355,7 +544,7 @@ func TestVote(t *testing.T) {
 	require.NoError(t, err)
 	eventName, eventPayload = chaincodeStub.SetEventArgsForCall(1)
 	require.Equal(t, "prepareToDeployEvent.request-1", eventName)
-	require.JSONEq(t, string(expectedEventDetailJSON), string(eventPayload))
+	require.JSONEq(t, string(expectedEventDetailJSON), string(eventData(eventPayload)))
 
 	// Case: Fail to vote for the proposal and the the status is already approved
 	baseProposal, _ = baseProposalAndInput(formattedTS)
@@ -374,6 +563,7 @@ func TestVoteWhenRejected(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -388,13 +578,13 @@ func TestVoteWhenRejected(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
 
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	historyOrg1 := History{
 		ObjectType: HistoryObjectType,
 		ProposalID: "request-1",
@@ -403,7 +593,7 @@ func TestVoteWhenRejected(t *testing.T) {
 		Status:     Agreed,
 		Time:       formattedTS,
 	}
-	chaincodeStub.GetStateReturnsOnCall(1, nil, nil) // No history for org2
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil) // No history for org2
 
 	historyOrg1JSON, err := json.Marshal(historyOrg1)
 	iterator := &mocks.StateQueryIterator{}
@@ -429,19 +619,21 @@ func TestVoteWhenRejected(t *testing.T) {
 	require.JSONEq(t, string(historyOrg2JSON), string(state))
 	key, state = chaincodeStub.PutStateArgsForCall(1)
 	baseProposal.Status = Rejected
+	baseProposal.UpdatedBy = "Org2MSP"
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(baseProposalJSON), string(state))
 
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "rejectedEvent.request-1", eventName)
-	require.Equal(t, []byte(nil), eventPayload)
+	require.Equal(t, []byte(nil), eventData(eventPayload))
 }
 
 func TestVoteWhenTryingUpdate(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -455,12 +647,12 @@ func TestVoteWhenTryingUpdate(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	historyOrg1 := History{
 		ObjectType: HistoryObjectType,
 		ProposalID: "request-1",
@@ -470,7 +662,7 @@ func TestVoteWhenTryingUpdate(t *testing.T) {
 		Time:       formattedTS,
 	}
 	historyOrg1JSON, err := json.Marshal(historyOrg1)
-	chaincodeStub.GetStateReturnsOnCall(1, historyOrg1JSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, historyOrg1JSON, nil)
 
 	err = sc.Vote(transactionContext, request)
 	require.EqualError(t, err, "failed to put the history: the state is already exists: Org1MSP")
@@ -480,6 +672,7 @@ func TestVoteWithInvalidInputParameters(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -503,6 +696,7 @@ func TestVoteWhenPutProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -515,16 +709,16 @@ func TestVoteWhenPutProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Prepare a state for GetProposal()
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	// Prepare a state for getting history (there is no state for the voting from Org2)
-	chaincodeStub.GetStateReturnsOnCall(1, nil, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
 
 	// Prepare states for GetHistories()
 	historyOrg1 := History{
@@ -548,7 +742,7 @@ func TestVoteWhenPutProposalFails(t *testing.T) {
 	}
 	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+4, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Vote(transactionContext, request)
 	require.EqualError(t, err, "failed to update the status: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -557,6 +751,7 @@ func TestVoteWhenSetEventErrorOccurs(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -569,16 +764,16 @@ func TestVoteWhenSetEventErrorOccurs(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Prepare a state for GetProposal()
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	// Prepare a state for getting history (there is no state for the voting from Org2)
-	chaincodeStub.GetStateReturnsOnCall(1, nil, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
 
 	// Prepare states for GetHistories()
 	iterator := &mocks.StateQueryIterator{}
@@ -595,6 +790,7 @@ func TestVoteWhenPutHistoryFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -607,7 +803,7 @@ func TestVoteWhenPutHistoryFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
@@ -616,14 +812,14 @@ func TestVoteWhenPutHistoryFails(t *testing.T) {
 
 	// Case: Fail to vote when putHistory occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Vote(transactionContext, request)
 	require.EqualError(t, err, "failed to put the history: error happened creating composite key for history: failed to create composite key")
 
 	// Case: Internal state read error to check whether overwrite or not
 	getStateCount := chaincodeStub.GetStateCallCount()
-	chaincodeStub.GetStateReturnsOnCall(getStateCount, baseProposalJSON, nil)                           // proposal
-	chaincodeStub.GetStateReturnsOnCall(getStateCount+1, nil, fmt.Errorf("unable to retrieve history")) // history
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+1, baseProposalJSON, nil)                         // proposal
+	chaincodeStub.GetStateReturnsOnCall(getStateCount+2, nil, fmt.Errorf("unable to retrieve history")) // history
 	err = sc.Vote(transactionContext, request)
 	require.EqualError(t, err, "failed to put the history: failed to read from world state: unable to retrieve history")
 }
@@ -632,6 +828,7 @@ func TestVoteWhenGetProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -644,7 +841,7 @@ func TestVoteWhenGetProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
@@ -653,7 +850,7 @@ func TestVoteWhenGetProposalFails(t *testing.T) {
 
 	// Case: Fail to vote when getProposal occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Vote(transactionContext, request)
 	require.EqualError(t, err, "failed to get the proposal: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -662,6 +859,7 @@ func TestVoteWhenChaincodeToChaincodeCallFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeReturns(peer.Response{
 		Status:  shim.ERROR,
@@ -677,16 +875,16 @@ func TestVoteWhenChaincodeToChaincodeCallFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Prepare a state for GetProposal()
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	// Prepare a state for getting history (there is no state for the voting from Org2)
-	chaincodeStub.GetStateReturnsOnCall(1, nil, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
 
 	// Prepare states for GetHistories()
 	historyOrg1 := History{
@@ -713,6 +911,7 @@ func TestAcknowledge(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -726,13 +925,13 @@ func TestAcknowledge(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Approved
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
@@ -757,7 +956,7 @@ func TestAcknowledge(t *testing.T) {
 	require.Equal(t, 0, setEventCallCount)
 
 	// Case: acknowledge for the proposal and the proposal is acknowledged by ALL organizations
-	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, baseProposalJSON, nil)
 	historyOrg1 := History{
 		ObjectType: HistoryObjectType,
 		ProposalID: "request-1",
@@ -767,11 +966,14 @@ func TestAcknowledge(t *testing.T) {
 		Time:       formattedTS,
 	}
 	historyOrg1JSON, err := json.Marshal(historyOrg1)
-	iterator = &mocks.StateQueryIterator{}
-	iterator.HasNextReturnsOnCall(0, true)
-	iterator.HasNextReturnsOnCall(1, false)
-	iterator.NextReturnsOnCall(0, &queryresult.KV{Value: historyOrg1JSON}, nil)
-	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	require.NoError(t, err)
+	chaincodeStub.GetStateByPartialCompositeKeyStub = func(string, []string) (shim.StateQueryIteratorInterface, error) {
+		iterator := &mocks.StateQueryIterator{}
+		iterator.HasNextReturnsOnCall(0, true)
+		iterator.HasNextReturnsOnCall(1, false)
+		iterator.NextReturnsOnCall(0, &queryresult.KV{Value: historyOrg1JSON}, nil)
+		return iterator, nil
+	}
 
 	err = sc.Acknowledge(transactionContext, request)
 	require.NoError(t, err)
@@ -783,6 +985,8 @@ func TestAcknowledge(t *testing.T) {
 
 	key, state = chaincodeStub.PutStateArgsForCall(2)
 	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org2MSP"}
+	baseProposal.UpdatedBy = "Org2MSP"
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(baseProposalJSON), string(state))
@@ -795,7 +999,7 @@ func TestAcknowledge(t *testing.T) {
 	require.NoError(t, err)
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "deployEvent.request-1", eventName)
-	require.JSONEq(t, string(expectedEventDetailJSON), string(eventPayload))
+	require.JSONEq(t, string(expectedEventDetailJSON), string(eventData(eventPayload)))
 
 	// Case: Acknowledge for the proposal and the the status is already acknowledged
 	baseProposal, _ = baseProposalAndInput(formattedTS)
@@ -810,10 +1014,146 @@ func TestAcknowledge(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAcknowledgeFailureAndRetry(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Prepare the approved proposal
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+
+	// Case: Fail to retry the proposal which is not failed
+	err = sc.RetryProposal(transactionContext, "request-1")
+	require.EqualError(t, err, "only the failed proposal can be retried")
+
+	// Case: The proposal is failed when an org reports an acknowledge failure
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure, Data: "failed to install"})
+	require.NoError(t, err)
+
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Failed, proposal.Status)
+	require.Equal(t, Acknowledge, proposal.FailedTask)
+
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "failedEvent.request-1", eventName)
+	var eventDetail FailureEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Equal(t, Acknowledge, eventDetail.TaskID)
+	require.Equal(t, map[string]string{"Org2MSP": "failed to install"}, eventDetail.FailedOrgs)
+
+	// Case: Retry the acknowledge task of the failed proposal
+	err = sc.RetryProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, proposal.Status)
+	require.Empty(t, proposal.FailedTask)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "prepareToDeployEvent.request-1", eventName)
+
+	err = sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Acknowledged, proposal.Status)
+
+	// Case: The proposal is failed when the commit fails and the commit task is retried
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Failed, proposal.Status)
+	require.Equal(t, Commit, proposal.FailedTask)
+
+	// Case: Fail to retry the failed commit by the org which is neither the operation target nor the proposer
+	chaincodeStub.GetCreatorReturns(org3MSP, nil)
+	err = sc.RetryProposal(transactionContext, "request-1")
+	require.ErrorIs(t, err, ErrNotAllowedToRetry)
+
+	// Case: The proposer retries the failed commit, and the operation targets are kept
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.RetryProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Acknowledged, proposal.Status)
+	require.Equal(t, []string{"Org2MSP"}, proposal.OperationTargets)
+	eventName, eventPayload = lastEvent(chaincodeStub)
+	require.Equal(t, "deployEvent.request-1", eventName)
+	var deploymentEventDetail DeploymentEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &deploymentEventDetail))
+	require.Equal(t, []string{"Org2MSP"}, deploymentEventDetail.OperationTargets)
+
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.ErrorIs(t, err, ErrNotOperationTarget)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Committed, proposal.Status)
+}
+
+func TestRetryProposalIgnoresPreviousFailures(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Prepare the proposal failed by the acknowledge failure of Org2
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"}))
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure, Data: "failed to install"}))
+
+	// Case: The failure of Org2 reported before the retry is not included in the failed orgs
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(time.Minute)), nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.RetryProposal(transactionContext, "request-1"))
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Minute).UTC().Format(time.RFC3339), proposal.RetriedAt)
+
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(2*time.Minute)), nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure, Data: "failed to approve"}))
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "failedEvent.request-1", eventName)
+	var eventDetail FailureEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Equal(t, map[string]string{"Org1MSP": "failed to approve"}, eventDetail.FailedOrgs)
+}
+
 func TestAcknowledgeWithInvalidInputParameters(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -837,6 +1177,7 @@ func TestAcknowledgeWhenPutProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -849,7 +1190,7 @@ func TestAcknowledgeWhenPutProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Approved
@@ -866,11 +1207,14 @@ func TestAcknowledgeWhenPutProposalFails(t *testing.T) {
 		Time:       formattedTS,
 	}
 	historyOrg1JSON, err := json.Marshal(historyOrg1)
-	iterator := &mocks.StateQueryIterator{}
-	iterator.HasNextReturnsOnCall(0, true)
-	iterator.HasNextReturnsOnCall(1, false)
-	iterator.NextReturnsOnCall(0, &queryresult.KV{Value: historyOrg1JSON}, nil)
-	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	require.NoError(t, err)
+	chaincodeStub.GetStateByPartialCompositeKeyStub = func(string, []string) (shim.StateQueryIteratorInterface, error) {
+		iterator := &mocks.StateQueryIterator{}
+		iterator.HasNextReturnsOnCall(0, true)
+		iterator.HasNextReturnsOnCall(1, false)
+		iterator.NextReturnsOnCall(0, &queryresult.KV{Value: historyOrg1JSON}, nil)
+		return iterator, nil
+	}
 
 	// Case: Fail to acknowledge when putProposal occurs an error
 	request = TaskStatusUpdateRequest{
@@ -878,7 +1222,7 @@ func TestAcknowledgeWhenPutProposalFails(t *testing.T) {
 	}
 	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+4, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Acknowledge(transactionContext, request)
 	require.EqualError(t, err, "failed to update the status: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -887,6 +1231,7 @@ func TestAcknowledgeWhenPutHistoryFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -899,7 +1244,7 @@ func TestAcknowledgeWhenPutHistoryFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Approved
@@ -909,7 +1254,7 @@ func TestAcknowledgeWhenPutHistoryFails(t *testing.T) {
 
 	// Case: Fail to acknowledge when putHistory occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Acknowledge(transactionContext, request)
 	require.EqualError(t, err, "failed to put the history: error happened creating composite key for history: failed to create composite key")
 }
@@ -918,6 +1263,7 @@ func TestAcknowledgeWhenGetProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -930,7 +1276,7 @@ func TestAcknowledgeWhenGetProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Approved
@@ -940,7 +1286,7 @@ func TestAcknowledgeWhenGetProposalFails(t *testing.T) {
 
 	// Case: Fail to acknowledge when getProposal occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Acknowledge(transactionContext, request)
 	require.EqualError(t, err, "failed to get the proposal: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -949,6 +1295,7 @@ func TestAcknowledgeWhenChaincodeToChaincodeCallFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeReturns(peer.Response{
 		Status:  shim.ERROR,
@@ -964,7 +1311,7 @@ func TestAcknowledgeWhenChaincodeToChaincodeCallFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Approved
@@ -989,13 +1336,14 @@ func TestAcknowledgeWhenChaincodeToChaincodeCallFails(t *testing.T) {
 
 	// Case: Fail to acknowledge when chaincode to chaincode fails
 	err = sc.Acknowledge(transactionContext, request)
-	require.EqualError(t, err, "failed to do meetCriteria: failed to call count organization in channel (code: 500, message: error)")
+	require.EqualError(t, err, "failed to do meetAcknowledgeCriteria: failed to call get organizations in channel (code: 500, message: error)")
 }
 
 func TestNotifyCommitResult(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1009,16 +1357,18 @@ func TestNotifyCommitResult(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org2MSP"}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	chaincodeStub.GetQueryResultReturns(&mocks.StateQueryIterator{}, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, request)
 	require.NoError(t, err)
@@ -1038,15 +1388,16 @@ func TestNotifyCommitResult(t *testing.T) {
 
 	key, state = chaincodeStub.PutStateArgsForCall(1)
 	baseProposal.Status = Committed
+	baseProposal.UpdatedBy = "Org2MSP"
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(baseProposalJSON), string(state))
 
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "committedEvent.request-1", eventName)
-	require.Equal(t, []byte(nil), eventPayload)
+	require.Equal(t, []byte(nil), eventData(eventPayload))
 
-	// Case: Notify commit for the proposal and the the status is already committed
+	// Case: Fail to notify commit for the proposal when the status is already committed
 	baseProposal, _ = baseProposalAndInput(formattedTS)
 	baseProposal.Status = Committed
 	baseProposalJSON, err = json.Marshal(baseProposal)
@@ -1055,14 +1406,75 @@ func TestNotifyCommitResult(t *testing.T) {
 	iterator = &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	putStateCount := chaincodeStub.PutStateCallCount()
 	err = sc.NotifyCommitResult(transactionContext, request)
+	require.ErrorIs(t, err, ErrProposalNotAcknowledged)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure})
+	require.ErrorIs(t, err, ErrProposalNotAcknowledged)
+	require.Equal(t, putStateCount, chaincodeStub.PutStateCallCount())
+
+	// Case: Fail to notify commit for the proposal without the operation targets by the org which has not acknowledged it
+	baseProposal.Status = Acknowledged
+	baseProposalJSON, err = json.Marshal(baseProposal)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure})
+	require.ErrorIs(t, err, ErrNotOperationTarget)
+	require.Equal(t, putStateCount, chaincodeStub.PutStateCallCount())
+
+	// Case: Fail to notify commit for the proposal by the org which is not the operation target
+	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org1MSP"}
+	baseProposalJSON, err = json.Marshal(baseProposal)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
+	err = sc.NotifyCommitResult(transactionContext, request)
+	require.ErrorIs(t, err, ErrNotOperationTarget)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Failure})
+	require.ErrorIs(t, err, ErrNotOperationTarget)
+	require.Equal(t, putStateCount, chaincodeStub.PutStateCallCount())
+}
+
+func TestNotifyCommitResultWithoutOperationTargets(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	ws := newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Prepare: The proposal acknowledged by Org1MSP before the operation targets are recorded
+	proposal, _ := baseProposalAndInput("")
+	proposal.Status = Acknowledged
+	proposalJSON, err := json.Marshal(proposal)
+	require.NoError(t, err)
+	ws["proposal_request-1"] = proposalJSON
+	historyJSON, err := json.Marshal(History{ObjectType: HistoryObjectType, ProposalID: "request-1", OrgID: "Org1MSP", TaskID: Acknowledge, Status: Success})
+	require.NoError(t, err)
+	ws["history_request-1_acknowledge_Org1MSP"] = historyJSON
+
+	// Case: Fail to notify commit by the org which has not acknowledged the proposal
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.ErrorIs(t, err, ErrNotOperationTarget)
+
+	// Case: Notify commit by the org which has acknowledged the proposal
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.NoError(t, err)
+	actual, err := sc.GetProposal(transactionContext, "request-1")
 	require.NoError(t, err)
+	require.Equal(t, Committed, actual.Status)
 }
 
 func TestNotifyCommitResultWhenTaskIsFailure(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1077,13 +1489,14 @@ func TestNotifyCommitResultWhenTaskIsFailure(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org2MSP"}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, false)
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
@@ -1104,14 +1517,32 @@ func TestNotifyCommitResultWhenTaskIsFailure(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, string(historyOrg2JSON), string(state))
 
-	setEventCount := chaincodeStub.SetEventCallCount()
-	require.Equal(t, 0, setEventCount)
+	key, state = chaincodeStub.PutStateArgsForCall(1)
+	require.Equal(t, "proposal_request-1", key)
+	baseProposal.Status = Failed
+	baseProposal.FailedTask = Commit
+	baseProposal.UpdatedBy = "Org2MSP"
+	baseProposalJSON, err = json.Marshal(baseProposal)
+	require.NoError(t, err)
+	require.JSONEq(t, string(baseProposalJSON), string(state))
+
+	expectedEventDetail := FailureEventDetail{
+		Proposal:   baseProposal,
+		TaskID:     Commit,
+		FailedOrgs: map[string]string{"Org2MSP": ""},
+	}
+	expectedEventDetailJSON, err := json.Marshal(expectedEventDetail)
+	require.NoError(t, err)
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
+	require.Equal(t, "failedEvent.request-1", eventName)
+	require.JSONEq(t, string(expectedEventDetailJSON), string(eventData(eventPayload)))
 }
 
 func TestNotifyCommitWithInvalidInputParameters(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1135,6 +1566,7 @@ func TestNotifyCommitResultWhenPutProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1147,10 +1579,11 @@ func TestNotifyCommitResultWhenPutProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org2MSP"}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
@@ -1176,7 +1609,7 @@ func TestNotifyCommitResultWhenPutProposalFails(t *testing.T) {
 	}
 	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+3, "", fmt.Errorf("failed to create composite key"))
 	err = sc.NotifyCommitResult(transactionContext, request)
 	require.EqualError(t, err, "failed to update the status: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -1185,6 +1618,7 @@ func TestNotifyCommitResultWhenPutHistoryFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1197,17 +1631,18 @@ func TestNotifyCommitResultWhenPutHistoryFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Acknowledged
+	baseProposal.OperationTargets = []string{"Org2MSP"}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(baseProposalJSON, nil)
 
 	// Case: Fail to notify commit when putHistory occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+2, "", fmt.Errorf("failed to create composite key"))
 	err = sc.NotifyCommitResult(transactionContext, request)
 	require.EqualError(t, err, "failed to put the history: error happened creating composite key for history: failed to create composite key")
 }
@@ -1216,6 +1651,7 @@ func TestNotifyCommitResultWhenGetProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1228,7 +1664,7 @@ func TestNotifyCommitResultWhenGetProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposal.Status = Acknowledged
@@ -1238,7 +1674,7 @@ func TestNotifyCommitResultWhenGetProposalFails(t *testing.T) {
 
 	// Case: Fail to notify commit when getProposal occurs an error
 	createComposeKeyCount := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(createComposeKeyCount+1, "", fmt.Errorf("failed to create composite key"))
 	err = sc.NotifyCommitResult(transactionContext, request)
 	require.EqualError(t, err, "failed to get the proposal: error happened creating composite key for proposal: failed to create composite key")
 }
@@ -1247,6 +1683,7 @@ func TestWithdrawProposal(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1257,31 +1694,33 @@ func TestWithdrawProposal(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	err = sc.WithdrawProposal(transactionContext, "request-1")
 	require.NoError(t, err)
 
 	_, state := chaincodeStub.PutStateArgsForCall(0)
 	baseProposal.Status = Withdrawn
+	baseProposal.UpdatedBy = "Org1MSP"
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(baseProposalJSON), string(state))
 
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "withdrawnEvent.request-1", eventName)
-	require.Equal(t, []byte(nil), eventPayload)
+	require.Equal(t, []byte(nil), eventData(eventPayload))
 }
 
 func TestWithdrawProposalFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	chaincodeStub.InvokeChaincodeStub = invokeChaincode
 
@@ -1300,7 +1739,7 @@ func TestWithdrawProposalFails(t *testing.T) {
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	baseProposal, _ := baseProposalAndInput(formattedTS)
 	baseProposalJSON, err := json.Marshal(baseProposal)
@@ -1322,16 +1761,121 @@ func TestWithdrawProposalFails(t *testing.T) {
 	require.EqualError(t, err, "the voting is already closed")
 }
 
+func TestExpireProposals(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	now := time.Now()
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: now.Unix()}, nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+
+	// Case: Request proposals with and without the voting deadline
+	_, input := baseProposalAndInput("")
+	input.VotingDeadline = now.Add(time.Hour).Format(time.RFC3339)
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	_, input = baseProposalAndInput("")
+	input.ID = "request-2"
+	input.ChaincodeName = "basic2"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	// Case: Fail to request when the voting deadline is invalid
+	_, input = baseProposalAndInput("")
+	input.ID = "request-3"
+	input.VotingDeadline = "tomorrow"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.ErrorContains(t, err, "the parameter 'VotingDeadline' should be RFC3339 format")
+
+	// Case: Fail to request when the voting deadline has already passed
+	input.VotingDeadline = now.Add(-time.Hour).Format(time.RFC3339)
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the parameter 'VotingDeadline' should be later than the current time")
+
+	// Case: No proposals are expired before the deadline
+	expiredIDs, err := sc.ExpireProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, expiredIDs)
+
+	// Case: Fail to vote for the proposal after the deadline even if the proposal is not yet expired
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: now.Add(2 * time.Hour).Unix()}, nil)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.EqualError(t, err, "the proposal is expired")
+
+	// Case: Expire the overdue proposal
+	setEventCount := chaincodeStub.SetEventCallCount()
+	expiredIDs, err = sc.ExpireProposals(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1"}, expiredIDs)
+	require.Equal(t, setEventCount+1, chaincodeStub.SetEventCallCount())
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "expiredEvent", eventName)
+	var expiredProposals []ChaincodeUpdateProposal
+	require.NoError(t, json.Unmarshal(eventPayload, &expiredProposals))
+	require.Len(t, expiredProposals, 1)
+	require.Equal(t, "request-1", expiredProposals[0].ID)
+	require.Equal(t, Expired, expiredProposals[0].Status)
+
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Expired, proposal.Status)
+	proposal, err = sc.GetProposal(transactionContext, "request-2")
+	require.NoError(t, err)
+	require.Equal(t, Proposed, proposal.Status)
+
+	// Case: Fail to vote, acknowledge and withdraw the expired proposal
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.EqualError(t, err, "the proposal is expired")
+	err = sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	require.EqualError(t, err, "the proposal is expired")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.WithdrawProposal(transactionContext, "request-1")
+	require.EqualError(t, err, "the proposal is expired")
+
+	// Case: Expire the overdue proposal by scanning the all proposals if rich queries are not supported
+	_, input = baseProposalAndInput("")
+	input.ID = "request-4"
+	input.ChaincodeName = "basic4"
+	input.VotingDeadline = now.Add(3 * time.Hour).Format(time.RFC3339)
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: now.Add(4 * time.Hour).Unix()}, nil)
+	queryResultStub := chaincodeStub.GetQueryResultStub
+	chaincodeStub.GetQueryResultStub = func(string) (shim.StateQueryIteratorInterface, error) {
+		return nil, fmt.Errorf("ExecuteQuery not supported for leveldb")
+	}
+	expiredIDs, err = sc.ExpireProposals(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-4"}, expiredIDs)
+	chaincodeStub.GetQueryResultStub = queryResultStub
+
+	// Case: Expired proposals are not expired again
+	setEventCount = chaincodeStub.SetEventCallCount()
+	expiredIDs, err = sc.ExpireProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, expiredIDs)
+	require.Equal(t, setEventCount, chaincodeStub.SetEventCallCount())
+}
+
 func TestGetAllProposals(t *testing.T) {
 
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Case: Get 2 proposals
 	proposal1, _ := baseProposalAndInput(formattedTS)
@@ -1373,11 +1917,12 @@ func TestGetProposal(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Case: Get the proposal
 	expected, _ := baseProposalAndInput(formattedTS)
@@ -1415,11 +1960,12 @@ func TestGetHistories(t *testing.T) {
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 	timestamp := ptypes.TimestampNow()
 	chaincodeStub.GetTxTimestampReturns(timestamp, nil)
 	ts := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
-	formattedTS := ts.Format(time.RFC3339)
+	formattedTS := ts.UTC().Format(time.RFC3339)
 
 	// Case: Get histories
 
@@ -1559,6 +2105,9 @@ func baseProposalAndInput(formattedTimeStamp string) (ChaincodeUpdateProposal, C
 		},
 		ChaincodeDefinition: ChaincodeDefinition{
 			Sequence:            1,
+			Version:             "1",
+			EndorsementPlugin:   DefaultEndorsementPlugin,
+			ValidationPlugin:    DefaultValidationPlugin,
 			InitRequired:        false,
 			ValidationParameter: "L0NoYW5uZWwvQXBwbGljYXRpb24vRW5kb3JzZW1lbnQ=",
 		},
@@ -1590,6 +2139,7 @@ func TestSetMaxMaliciousOrgsInVotes(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	sc := SmartContract{}
 
@@ -1606,33 +2156,25 @@ func TestSetMaxMaliciousOrgsInVotes(t *testing.T) {
 	key, state := chaincodeStub.PutStateArgsForCall(0)
 	require.Equal(t, "votingConfig", key)
 	require.JSONEq(t, string(expectedJSON), string(state))
+	key, state = chaincodeStub.PutStateArgsForCall(1)
+	require.Equal(t, VotingConfigBootstrappedKey, key)
+	require.Equal(t, "true", string(state))
 
 	// Case: Fail to set voting config when the number is invalid
 	err = sc.SetMaxMaliciousOrgsInVotes(transactionContext, -1)
 	require.EqualError(t, err, "number of max malicious orgs in votes should be greater than 0")
-}
 
-func TestUnsetMaxMaliciousOrgsInVotes(t *testing.T) {
-	chaincodeStub := &mocks.ChaincodeStub{}
-	transactionContext := &mocks.TransactionContext{}
-	transactionContext.GetStubReturns(chaincodeStub)
-
-	sc := SmartContract{}
-
-	// Case: UnSet voting config
-	err := sc.UnsetMaxMaliciousOrgsInVotes(transactionContext)
-	require.NoError(t, err)
-
-	// Case: Fail when an internal error occurs
-	chaincodeStub.DelStateReturns(fmt.Errorf("fail to delete voting config"))
-	err = sc.UnsetMaxMaliciousOrgsInVotes(transactionContext)
-	require.EqualError(t, err, "error happened delete the voting config from the ledger: fail to delete voting config")
+	// Case: Fail to set voting config when the voting config is already set
+	chaincodeStub.GetStateReturns(expectedJSON, nil)
+	err = sc.SetMaxMaliciousOrgsInVotes(transactionContext, 0)
+	require.EqualError(t, err, "voting config is already set (use a governance proposal to change it)")
 }
 
 func TestGetVotingConfig(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 
 	// Case: Get null
@@ -1661,3 +2203,89 @@ func TestGetVotingConfig(t *testing.T) {
 	_, err = sc.GetVotingConfig(transactionContext)
 	require.EqualError(t, err, "error happened reading voting config: unable to retrieve voting config")
 }
+
+func TestVotingConfigPerChannel(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	ws := newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// Prepare the default voting config which requires 3 votes
+	err := sc.SetMaxMaliciousOrgsInVotes(transactionContext, 1)
+	require.NoError(t, err)
+	defaultConfig := &VotingConfig{ObjectType: VotingConfigObjectType, MaxMaliciousOrgs: 1}
+
+	// Case: Fall back to the default voting config when the config for the channel is not set
+	config, err := sc.GetEffectiveVotingConfig(transactionContext, "mychannel")
+	require.NoError(t, err)
+	require.Equal(t, defaultConfig, config)
+
+	// Case: Set the voting config for the channel which requires only 1 vote through a governance proposal
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:           "governance-1",
+		VotingConfig: VotingConfig{ChannelID: "mychannel", MaxMaliciousOrgs: 0},
+	})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-1"})
+	require.NoError(t, err)
+	require.NotNil(t, ws["votingConfig_mychannel"])
+
+	config, err = sc.GetEffectiveVotingConfig(transactionContext, "mychannel")
+	require.NoError(t, err)
+	require.Equal(t, &VotingConfig{ObjectType: VotingConfigObjectType, ChannelID: "mychannel", MaxMaliciousOrgs: 0}, config)
+
+	config, err = sc.GetEffectiveVotingConfig(transactionContext, "otherchannel")
+	require.NoError(t, err)
+	require.Equal(t, defaultConfig, config)
+
+	config, err = sc.GetVotingConfig(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, defaultConfig, config)
+
+	// Case: The proposal for the channel is approved by the vote of the proposer with the config for the channel
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, proposal.Status)
+
+	// Case: The proposal for another channel is not approved with the default config
+	_, input = baseProposalAndInput("")
+	input.ID = "request-2"
+	input.ChannelID = "otherchannel"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-2", Status: Agreed})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-2")
+	require.NoError(t, err)
+	require.Equal(t, Proposed, proposal.Status)
+
+	// Case: Unset the voting config for the channel and fall back to the default voting config
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:           "governance-2",
+		Action:       UnsetVotingConfigAction,
+		VotingConfig: VotingConfig{ChannelID: "mychannel"},
+	})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-2"})
+	require.NoError(t, err)
+
+	config, err = sc.GetEffectiveVotingConfig(transactionContext, "mychannel")
+	require.NoError(t, err)
+	require.Equal(t, defaultConfig, config)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ChaincodeState describes the deployment state of a chaincode on a channel, and which is stored as a state in the ledger.
+type ChaincodeState struct {
+	ObjectType            string `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	ChannelID             string `json:"channelID"`
+	ChaincodeName         string `json:"chaincodeName"`
+	LastCommittedSequence int64  `json:"lastCommittedSequence"`
+	InFlightProposal      string `json:"inFlightProposal,omitempty" metadata:",optional"` // the ID of the open proposal for the chaincode (if any)
+}
+
+// Object types
+const (
+	ChaincodeStateObjectType = "chaincodeState"
+)
+
+// GetChaincodeState returns the deployment state of the chaincode on the channel.
+// The state has the last sequence committed through chaincode-ops and the open (not yet decided or deployed) proposal for the chaincode.
+// The last committed sequence is 0 if the chaincode has never been committed through chaincode-ops
+// (even if it has been committed outside chaincode-ops or before the chaincode state is introduced).
+//
+// Arguments:
+//   0: channelID - the channel ID of the chaincode
+//   1: chaincodeName - the name of the chaincode
+//
+// Returns:
+//   0: the chaincode state
+//   1: error
+//
+func (s *SmartContract) GetChaincodeState(ctx contractapi.TransactionContextInterface, channelID string, chaincodeName string) (*ChaincodeState, error) {
+
+	// Validate input
+	if channelID == "" {
+		return nil, fmt.Errorf("the required parameter 'channelID' is empty")
+	}
+
+	if chaincodeName == "" {
+		return nil, fmt.Errorf("the required parameter 'chaincodeName' is empty")
+	}
+
+	state, err := s.getChaincodeState(ctx, channelID, chaincodeName)
+	if err != nil {
+		return nil, err
+	}
+	if state.InFlightProposal != "" {
+		open, err := s.isOpenProposal(ctx, state.InFlightProposal)
+		if err != nil {
+			return nil, err
+		}
+		if !open {
+			state.InFlightProposal = ""
+		}
+	}
+	return state, nil
+}
+
+// -- Internal logics
+
+// claimChaincodeState records the proposal as the in-flight proposal for the chaincode.
+// This fails if the sequence of the proposal is not the next of the last committed sequence
+// or another proposal for the chaincode is still open.
+// If no sequence has been committed through chaincode-ops, any sequence is accepted,
+// because the chaincode may have been committed outside chaincode-ops (and _lifecycle checks the sequence on commit).
+func (s *SmartContract) claimChaincodeState(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	state, err := s.getChaincodeState(ctx, proposal.ChannelID, proposal.ChaincodeName)
+	if err != nil {
+		return err
+	}
+
+	if state.InFlightProposal != "" && state.InFlightProposal != proposal.ID {
+		open, err := s.isOpenProposal(ctx, state.InFlightProposal)
+		if err != nil {
+			return err
+		}
+		if open {
+			return fmt.Errorf("the chaincode already has an open proposal (%s)", state.InFlightProposal)
+		}
+	}
+
+	if state.LastCommittedSequence > 0 && proposal.ChaincodeDefinition.Sequence != state.LastCommittedSequence+1 {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.Sequence' should be %d (the last committed sequence is %d)",
+			state.LastCommittedSequence+1, state.LastCommittedSequence)
+	}
+
+	state.InFlightProposal = proposal.ID
+	return s.putChaincodeState(ctx, *state)
+}
+
+// releaseChaincodeState records the sequence of the committed proposal as the last committed sequence for the chaincode.
+func (s *SmartContract) releaseChaincodeState(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	state, err := s.getChaincodeState(ctx, proposal.ChannelID, proposal.ChaincodeName)
+	if err != nil {
+		return err
+	}
+	if proposal.ChaincodeDefinition.Sequence > state.LastCommittedSequence {
+		state.LastCommittedSequence = proposal.ChaincodeDefinition.Sequence
+	}
+	if state.InFlightProposal == proposal.ID {
+		state.InFlightProposal = ""
+	}
+	return s.putChaincodeState(ctx, *state)
+}
+
+// isOpenProposal returns whether the proposal is neither decided against nor finished.
+// The scheduled proposal whose deployment window has closed is not regarded as open because it is to be expired.
+// The failed proposal is not regarded as open because it can be superseded by a new proposal.
+func (s *SmartContract) isOpenProposal(ctx contractapi.TransactionContextInterface, proposalID string) (bool, error) {
+	proposal, err := s.GetProposal(ctx, proposalID)
+	if err == ErrProposalNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	switch proposal.Status {
+	case Proposed:
+		expired, err := s.isExpired(ctx, *proposal)
+		if err != nil {
+			return false, err
+		}
+		return !expired, nil
+	case Scheduled:
+		closed, err := s.isDeploymentWindowClosed(ctx, *proposal)
+		if err != nil {
+			return false, err
+		}
+		return !closed, nil
+	case Waiting, Approved, Acknowledged:
+		return true, nil
+	}
+	return false, nil
+}
+
+// getChaincodeState returns the chaincode state from the ledger.
+// If the state is not recorded yet, this returns the state built from the committed proposals.
+func (s *SmartContract) getChaincodeState(ctx contractapi.TransactionContextInterface, channelID string, chaincodeName string) (*ChaincodeState, error) {
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ChaincodeStateObjectType, []string{channelID, chaincodeName})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for chaincode state: %v", err)
+	}
+	stateJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading chaincode state: %v", err)
+	}
+
+	if stateJSON == nil {
+		lastSequence, err := s.getLastCommittedSequence(ctx, channelID, chaincodeName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the last committed sequence: %v", err)
+		}
+		return &ChaincodeState{
+			ObjectType:            ChaincodeStateObjectType,
+			ChannelID:             channelID,
+			ChaincodeName:         chaincodeName,
+			LastCommittedSequence: lastSequence,
+		}, nil
+	}
+
+	var state ChaincodeState
+	if err = json.Unmarshal(stateJSON, &state); err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling a chaincode state JSON representation to struct: %v", err)
+	}
+	return &state, nil
+}
+
+func (s *SmartContract) putChaincodeState(ctx contractapi.TransactionContextInterface, state ChaincodeState) error {
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ChaincodeStateObjectType, []string{state.ChannelID, state.ChaincodeName})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for chaincode state: %v", err)
+	}
+
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the chaincode state: %v", err)
+	}
+
+	if err = ctx.GetStub().PutState(compositeKey, stateJSON); err != nil {
+		return fmt.Errorf("error happened persisting the chaincode state on the ledger: %v", err)
+	}
+	return nil
+}
+
+// getLastCommittedSequence returns the largest sequence of the committed proposals for the given chaincode.
+func (s *SmartContract) getLastCommittedSequence(ctx contractapi.TransactionContextInterface, channelID string, chaincodeName string) (int64, error) {
+	proposals, err := s.getProposalsByStatus(ctx, Committed)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastSequence int64
+	for _, proposal := range proposals {
+		if proposal.ChannelID != channelID || proposal.ChaincodeName != chaincodeName {
+			continue
+		}
+		if proposal.ChaincodeDefinition.Sequence > lastSequence {
+			lastSequence = proposal.ChaincodeDefinition.Sequence
+		}
+	}
+	return lastSequence, nil
+}
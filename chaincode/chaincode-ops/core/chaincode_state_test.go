@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestChaincodeState(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	now := time.Now()
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	requestProposal := func(proposalID string, sequence int64, votingDeadline string) error {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeDefinition.Sequence = sequence
+		input.VotingDeadline = votingDeadline
+		_, err := sc.RequestProposal(transactionContext, input)
+		return err
+	}
+	doTask := func(creator []byte, task func(contractapi.TransactionContextInterface, TaskStatusUpdateRequest) error, request TaskStatusUpdateRequest) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		require.NoError(t, task(transactionContext, request))
+	}
+	requireState := func(lastCommittedSequence int64, inFlightProposal string) {
+		state, err := sc.GetChaincodeState(transactionContext, "mychannel", "basic")
+		require.NoError(t, err)
+		require.Equal(t, &ChaincodeState{
+			ObjectType:            ChaincodeStateObjectType,
+			ChannelID:             "mychannel",
+			ChaincodeName:         "basic",
+			LastCommittedSequence: lastCommittedSequence,
+			InFlightProposal:      inFlightProposal,
+		}, state)
+	}
+
+	// Case: The chaincode which has never been proposed has no committed sequence
+	requireState(0, "")
+
+	// Case: The proposal is recorded as the in-flight proposal
+	require.NoError(t, requestProposal("request-1", 1, ""))
+	requireState(0, "request-1")
+
+	// Case: Fail to request a proposal colliding with the open proposal
+	require.EqualError(t, requestProposal("request-2", 1, ""), "the chaincode already has an open proposal (request-1)")
+	require.EqualError(t, requestProposal("request-2", 2, ""), "the chaincode already has an open proposal (request-1)")
+
+	// Case: The committed sequence is recorded and the chaincode is released
+	doTask(org2MSP, sc.Vote, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	doTask(org1MSP, sc.Acknowledge, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	doTask(org2MSP, sc.Acknowledge, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	requireState(0, "request-1")
+	doTask(org2MSP, sc.NotifyCommitResult, TaskStatusUpdateRequest{ProposalID: "request-1"})
+	requireState(1, "")
+
+	// Case: Fail to request a proposal with a stale or skipped sequence
+	require.EqualError(t, requestProposal("request-2", 1, ""), "the parameter 'ChaincodeDefinition.Sequence' should be 2 (the last committed sequence is 1)")
+	require.EqualError(t, requestProposal("request-2", 3, ""), "the parameter 'ChaincodeDefinition.Sequence' should be 2 (the last committed sequence is 1)")
+
+	// Case: The withdrawn proposal does not block a new proposal
+	require.NoError(t, requestProposal("request-2", 2, ""))
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.WithdrawProposal(transactionContext, "request-2"))
+	requireState(1, "")
+
+	// Case: The proposal whose voting deadline has passed does not block a new proposal even before it is expired
+	require.NoError(t, requestProposal("request-3", 2, now.Add(time.Hour).Format(time.RFC3339)))
+	requireState(1, "request-3")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(2*time.Hour)), nil)
+	requireState(1, "")
+	require.NoError(t, requestProposal("request-4", 2, ""))
+
+	// Case: The failed proposal does not block a new proposal, and it cannot be retried after that
+	doTask(org2MSP, sc.Vote, TaskStatusUpdateRequest{ProposalID: "request-4"})
+	doTask(org2MSP, sc.Acknowledge, TaskStatusUpdateRequest{ProposalID: "request-4", Status: Failure})
+	requireState(1, "")
+	require.NoError(t, requestProposal("request-5", 2, ""))
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.EqualError(t, sc.RetryProposal(transactionContext, "request-4"), "the chaincode already has an open proposal (request-5)")
+
+	// Case: Get the state of the chaincode committed before the state is recorded
+	legacy, _ := baseProposalAndInput("")
+	legacy.ID = "legacy-1"
+	legacy.ChaincodeName = "legacy"
+	legacy.ChaincodeDefinition.Sequence = 3
+	legacy.Status = Committed
+	require.NoError(t, sc.putProposal(transactionContext, &legacy))
+	state, err := sc.GetChaincodeState(transactionContext, "mychannel", "legacy")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), state.LastCommittedSequence)
+
+	// Case: The first proposal for the chaincode committed outside chaincode-ops is accepted at any sequence,
+	// and the next one should follow the committed sequence
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	input.ID = "external-1"
+	input.ChaincodeName = "external"
+	input.ChaincodeDefinition.Sequence = 5
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	doTask(org2MSP, sc.Vote, TaskStatusUpdateRequest{ProposalID: "external-1"})
+	doTask(org1MSP, sc.Acknowledge, TaskStatusUpdateRequest{ProposalID: "external-1"})
+	doTask(org2MSP, sc.Acknowledge, TaskStatusUpdateRequest{ProposalID: "external-1"})
+	doTask(org2MSP, sc.NotifyCommitResult, TaskStatusUpdateRequest{ProposalID: "external-1"})
+	input.ID = "external-2"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the parameter 'ChaincodeDefinition.Sequence' should be 6 (the last committed sequence is 5)")
+
+	// Case: Fail to get the state with invalid parameters
+	_, err = sc.GetChaincodeState(transactionContext, "", "basic")
+	require.EqualError(t, err, "the required parameter 'channelID' is empty")
+	_, err = sc.GetChaincodeState(transactionContext, "mychannel", "")
+	require.EqualError(t, err, "the required parameter 'chaincodeName' is empty")
+}
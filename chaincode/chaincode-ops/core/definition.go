@@ -0,0 +1,239 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/policydsl"
+)
+
+// The formats of the chaincode definition accepted by the agents:
+// - ValidationParameter: a base64 encoded channel config policy reference (e.g., /Channel/Application/Endorsement),
+//   signature policy expression (e.g., OR('Org1MSP.peer', 'Org2MSP.peer')) or signature policy object in JSON.
+// - Collections: a base64 encoded JSON array of static collection configs (in the field names of the collection config package protobuf).
+
+// Default plugins of the chaincode definition (the same as the defaults of the peer)
+const (
+	DefaultEndorsementPlugin = "escc"
+	DefaultValidationPlugin  = "vscc"
+)
+
+var (
+	policyReferencePattern = regexp.MustCompile(`^(/[A-Za-z0-9._-]+)+$`)
+	collectionNamePattern  = regexp.MustCompile(`^[A-Za-z0-9-]+([A-Za-z0-9_-]+)*$`)
+	versionPattern         = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+	pluginNamePattern      = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// setChaincodeDefinitionDefaults sets the default values to the optional fields of the chaincode definition which are not set.
+// This is also applied to the proposals stored before the fields are introduced,
+// and the version defaults to the sequence since the agents have used the sequence as the version.
+func setChaincodeDefinitionDefaults(definition *ChaincodeDefinition) {
+	if definition.Version == "" {
+		definition.Version = strconv.FormatInt(definition.Sequence, 10)
+	}
+	if definition.EndorsementPlugin == "" {
+		definition.EndorsementPlugin = DefaultEndorsementPlugin
+	}
+	if definition.ValidationPlugin == "" {
+		definition.ValidationPlugin = DefaultValidationPlugin
+	}
+}
+
+// collectionConfig represents a static collection config in the collections of the chaincode definition.
+type collectionConfig struct {
+	Name               string                       `json:"name"`
+	MemberOrgsPolicy   json.RawMessage              `json:"member_orgs_policy,omitempty"`
+	Policy             json.RawMessage              `json:"policy,omitempty"` // alias of member_orgs_policy
+	RequiredPeerCount  int32                        `json:"required_peer_count"`
+	MaximumPeerCount   *int32                       `json:"maximum_peer_count,omitempty"`
+	MaxPeerCount       *int32                       `json:"maxPeerCount,omitempty"`   // alias of maximum_peer_count
+	LegacyMaxPeerCount *int32                       `json:"max_peer_count,omitempty"` // legacy alias of maximum_peer_count
+	BlockToLive        json.Number                  `json:"block_to_live,omitempty"`
+	MemberOnlyRead     bool                         `json:"member_only_read"`
+	MemberOnlyWrite    bool                         `json:"member_only_write"`
+	EndorsementPolicy  *collectionEndorsementPolicy `json:"endorsement_policy,omitempty"`
+}
+
+// collectionEndorsementPolicy represents an endorsement policy for a collection.
+type collectionEndorsementPolicy struct {
+	SignaturePolicy              json.RawMessage `json:"signature_policy,omitempty"`
+	ChannelConfigPolicyReference string          `json:"channel_config_policy_reference,omitempty"`
+}
+
+// signaturePolicyObject represents a signature policy in JSON (in the Fabric syntax with rule or the SDK syntax with policy).
+type signaturePolicyObject struct {
+	Version    int               `json:"version,omitempty"`
+	Identities []json.RawMessage `json:"identities"`
+	Rule       json.RawMessage   `json:"rule,omitempty"`
+	Policy     json.RawMessage   `json:"policy,omitempty"`
+}
+
+// validateChaincodeDefinition validates the version, the plugins, the validation parameter and the collections of the chaincode definition.
+func validateChaincodeDefinition(definition ChaincodeDefinition) error {
+	if !versionPattern.MatchString(definition.Version) {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.Version' should match %s", versionPattern)
+	}
+	if !pluginNamePattern.MatchString(definition.EndorsementPlugin) {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.EndorsementPlugin' should match %s", pluginNamePattern)
+	}
+	if !pluginNamePattern.MatchString(definition.ValidationPlugin) {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.ValidationPlugin' should match %s", pluginNamePattern)
+	}
+
+	validationParameter, err := base64.StdEncoding.DecodeString(definition.ValidationParameter)
+	if err != nil {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.ValidationParameter' should be base64 encoded: %v", err)
+	}
+	if err := validateApplicationPolicy(string(validationParameter)); err != nil {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.ValidationParameter' is invalid: %v", err)
+	}
+
+	if definition.Collections == "" {
+		return nil
+	}
+	collections, err := base64.StdEncoding.DecodeString(definition.Collections)
+	if err != nil {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.Collections' should be base64 encoded: %v", err)
+	}
+	if err := validateCollections(collections); err != nil {
+		return fmt.Errorf("the parameter 'ChaincodeDefinition.Collections' is invalid: %v", err)
+	}
+	return nil
+}
+
+// validateApplicationPolicy validates the policy given as a channel config policy reference or a signature policy.
+func validateApplicationPolicy(policy string) error {
+	if strings.HasPrefix(policy, "/") {
+		if !policyReferencePattern.MatchString(policy) {
+			return fmt.Errorf("invalid channel config policy reference: %s", policy)
+		}
+		return nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(policy), "{") {
+		return validateSignaturePolicyObject([]byte(policy))
+	}
+	return validateSignaturePolicyExpression(policy)
+}
+
+// validateSignaturePolicyExpression validates the signature policy expression (e.g., AND('Org1MSP.member', 'Org2MSP.member')).
+func validateSignaturePolicyExpression(policy string) error {
+	if _, err := policydsl.FromString(policy); err != nil {
+		return fmt.Errorf("invalid signature policy expression: %v", err)
+	}
+	return nil
+}
+
+// validateSignaturePolicyObject validates the structure of the signature policy in JSON.
+func validateSignaturePolicyObject(policy []byte) error {
+	var object signaturePolicyObject
+	if err := json.Unmarshal(policy, &object); err != nil {
+		return fmt.Errorf("invalid signature policy object: %v", err)
+	}
+	if len(object.Identities) == 0 {
+		return fmt.Errorf("invalid signature policy object: 'identities' is empty")
+	}
+	if (object.Rule == nil) == (object.Policy == nil) {
+		return fmt.Errorf("invalid signature policy object: either 'rule' or 'policy' should be set")
+	}
+	return nil
+}
+
+// validateSignaturePolicy validates the signature policy given as an expression (JSON string) or an object.
+func validateSignaturePolicy(policy json.RawMessage) error {
+	var expression string
+	if err := json.Unmarshal(policy, &expression); err == nil {
+		return validateSignaturePolicyExpression(expression)
+	}
+	return validateSignaturePolicyObject(policy)
+}
+
+// validateCollections validates the collections as a collection config package.
+func validateCollections(collections []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(collections))
+	decoder.DisallowUnknownFields()
+	var configs []collectionConfig
+	if err := decoder.Decode(&configs); err != nil {
+		return fmt.Errorf("failed to decode the collection configs: %v", err)
+	}
+	if len(configs) == 0 {
+		return fmt.Errorf("no collection config is found")
+	}
+
+	names := map[string]bool{}
+	for _, config := range configs {
+		if !collectionNamePattern.MatchString(config.Name) {
+			return fmt.Errorf("invalid collection name: '%s'", config.Name)
+		}
+		if names[config.Name] {
+			return fmt.Errorf("collection '%s' is defined more than once", config.Name)
+		}
+		names[config.Name] = true
+		if err := validateCollectionConfig(config); err != nil {
+			return fmt.Errorf("collection '%s': %v", config.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateCollectionConfig validates the static collection config.
+func validateCollectionConfig(config collectionConfig) error {
+	memberOrgsPolicy := config.MemberOrgsPolicy
+	if memberOrgsPolicy == nil {
+		memberOrgsPolicy = config.Policy
+	}
+	if memberOrgsPolicy == nil {
+		return fmt.Errorf("the member orgs policy is missing")
+	}
+	if err := validateSignaturePolicy(memberOrgsPolicy); err != nil {
+		return fmt.Errorf("invalid member orgs policy: %v", err)
+	}
+
+	if config.RequiredPeerCount < 0 {
+		return fmt.Errorf("the required peer count (%d) cannot be less than zero", config.RequiredPeerCount)
+	}
+	maximumPeerCount := config.MaximumPeerCount
+	if maximumPeerCount == nil {
+		maximumPeerCount = config.MaxPeerCount
+	}
+	if maximumPeerCount == nil {
+		maximumPeerCount = config.LegacyMaxPeerCount
+	}
+	if maximumPeerCount != nil && *maximumPeerCount < config.RequiredPeerCount {
+		return fmt.Errorf("the maximum peer count (%d) cannot be less than the required peer count (%d)", *maximumPeerCount, config.RequiredPeerCount)
+	}
+	if config.BlockToLive != "" {
+		if _, err := strconv.ParseUint(config.BlockToLive.String(), 10, 64); err != nil {
+			return fmt.Errorf("the block to live (%s) should be a non-negative integer", config.BlockToLive)
+		}
+	}
+
+	if config.EndorsementPolicy != nil {
+		signaturePolicy := config.EndorsementPolicy.SignaturePolicy
+		reference := config.EndorsementPolicy.ChannelConfigPolicyReference
+		switch {
+		case signaturePolicy != nil && reference != "":
+			return fmt.Errorf("only one of the signature policy and the channel config policy reference can be set for the endorsement policy")
+		case signaturePolicy != nil:
+			if err := validateSignaturePolicy(signaturePolicy); err != nil {
+				return fmt.Errorf("invalid endorsement policy: %v", err)
+			}
+		case reference != "":
+			if !policyReferencePattern.MatchString(reference) {
+				return fmt.Errorf("invalid endorsement policy: invalid channel config policy reference: %s", reference)
+			}
+		}
+	}
+	return nil
+}
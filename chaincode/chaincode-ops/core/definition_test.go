@@ -0,0 +1,163 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateChaincodeDefinition(t *testing.T) {
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	const collections = `[
+		{
+			"name": "assetCollection",
+			"member_orgs_policy": "OR('Org1MSP.member', 'Org2MSP.member')",
+			"required_peer_count": 1,
+			"maximum_peer_count": 1,
+			"block_to_live": 1000000,
+			"member_only_read": true,
+			"member_only_write": true
+		},
+		{
+			"name": "Org1MSPPrivateCollection",
+			"member_orgs_policy": {
+				"identities": [{"principal_classification": 0, "principal": {"msp_identifier": "Org1MSP", "role": "MEMBER"}}],
+				"rule": {"n_out_of": {"n": 1, "rules": [{"signed_by": 0}]}}
+			},
+			"required_peer_count": 0,
+			"maximum_peer_count": 1,
+			"block_to_live": 3,
+			"endorsement_policy": {"signature_policy": "OR('Org1MSP.member')"}
+		},
+		{
+			"name": "Org2MSPPrivateCollection",
+			"policy": "OR('Org2MSP.member')",
+			"maxPeerCount": 1,
+			"endorsement_policy": {
+				"signature_policy": {"identities": [{"role": {"name": "member", "mspId": "Org2MSP"}}], "policy": {"1-of": [{"signed-by": 0}]}}
+			}
+		},
+		{
+			"name": "Org3MSPPrivateCollection",
+			"policy": "OR('Org3MSP.member')",
+			"max_peer_count": 1
+		}
+	]`
+	collection := func(config string) string {
+		return encode(`[{"name": "collection", "member_orgs_policy": "OR('Org1MSP.member')"` + config + `}]`)
+	}
+
+	tests := []struct {
+		name                string
+		validationParameter string
+		collections         string
+		expectedErr         string
+	}{
+		{"channel config policy reference", encode("/Channel/Application/Endorsement"), "", ""},
+		{"signature policy expression", encode("AND('Org1MSP.peer', OutOf(1, 'Org2MSP.peer', 'Org3MSP.peer'))"), "", ""},
+		{"signature policy object", encode(`{"identities": [{"role": {"name": "peer", "mspId": "Org1MSP"}}], "policy": {"1-of": [{"signed-by": 0}]}}`), "", ""},
+		{"collections", encode("/Channel/Application/Endorsement"), encode(collections), ""},
+		{"not base64 encoded validation parameter", "OR('Org1MSP.peer')", "",
+			"the parameter 'ChaincodeDefinition.ValidationParameter' should be base64 encoded: illegal base64 data at input byte 2"},
+		{"malformed channel config policy reference", encode("/Channel//Endorsement"), "",
+			"the parameter 'ChaincodeDefinition.ValidationParameter' is invalid: invalid channel config policy reference: /Channel//Endorsement"},
+		{"signature policy object without rule", encode(`{"identities": [{"role": {"name": "peer", "mspId": "Org1MSP"}}]}`), "",
+			"the parameter 'ChaincodeDefinition.ValidationParameter' is invalid: invalid signature policy object: either 'rule' or 'policy' should be set"},
+		{"signature policy object without identities", encode(`{"identities": [], "policy": {"1-of": [{"signed-by": 0}]}}`), "",
+			"the parameter 'ChaincodeDefinition.ValidationParameter' is invalid: invalid signature policy object: 'identities' is empty"},
+		{"not base64 encoded collections", encode("/Channel/Application/Endorsement"), collections,
+			"the parameter 'ChaincodeDefinition.Collections' should be base64 encoded: illegal base64 data at input byte 0"},
+		{"empty collections", encode("/Channel/Application/Endorsement"), encode("[]"),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: no collection config is found"},
+		{"unknown field in collection", encode("/Channel/Application/Endorsement"), collection(`, "requiredPeerCount": 1`),
+			`the parameter 'ChaincodeDefinition.Collections' is invalid: failed to decode the collection configs: json: unknown field "requiredPeerCount"`},
+		{"invalid collection name", encode("/Channel/Application/Endorsement"), encode(`[{"name": "_collection", "member_orgs_policy": "OR('Org1MSP.member')"}]`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: invalid collection name: '_collection'"},
+		{"duplicated collection names", encode("/Channel/Application/Endorsement"), encode(`[{"name": "collection", "policy": "OR('Org1MSP.member')"}, {"name": "collection", "policy": "OR('Org2MSP.member')"}]`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection' is defined more than once"},
+		{"missing member orgs policy", encode("/Channel/Application/Endorsement"), encode(`[{"name": "collection"}]`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': the member orgs policy is missing"},
+		{"channel config policy reference as member orgs policy", encode("/Channel/Application/Endorsement"), encode(`[{"name": "collection", "member_orgs_policy": "/Channel/Application/Readers"}]`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': invalid member orgs policy: invalid signature policy expression: Cannot transition token types from UNKNOWN [<nil>] to MODIFIER [/]"},
+		{"negative required peer count", encode("/Channel/Application/Endorsement"), collection(`, "required_peer_count": -1`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': the required peer count (-1) cannot be less than zero"},
+		{"maximum peer count less than required peer count", encode("/Channel/Application/Endorsement"), collection(`, "required_peer_count": 2, "maximum_peer_count": 1`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': the maximum peer count (1) cannot be less than the required peer count (2)"},
+		{"legacy maximum peer count less than required peer count", encode("/Channel/Application/Endorsement"), collection(`, "required_peer_count": 2, "max_peer_count": 1`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': the maximum peer count (1) cannot be less than the required peer count (2)"},
+		{"negative block to live", encode("/Channel/Application/Endorsement"), collection(`, "block_to_live": -1`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': the block to live (-1) should be a non-negative integer"},
+		{"both endorsement policies", encode("/Channel/Application/Endorsement"), collection(`, "endorsement_policy": {"signature_policy": "OR('Org1MSP.member')", "channel_config_policy_reference": "/Channel/Application/Endorsement"}`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': only one of the signature policy and the channel config policy reference can be set for the endorsement policy"},
+		{"invalid endorsement policy", encode("/Channel/Application/Endorsement"), collection(`, "endorsement_policy": {"channel_config_policy_reference": "Endorsement"}`),
+			"the parameter 'ChaincodeDefinition.Collections' is invalid: collection 'collection': invalid endorsement policy: invalid channel config policy reference: Endorsement"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := ChaincodeDefinition{Sequence: 1, ValidationParameter: tt.validationParameter, Collections: tt.collections}
+			setChaincodeDefinitionDefaults(&definition)
+			err := validateChaincodeDefinition(definition)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestChaincodeDefinitionVersionAndPlugins(t *testing.T) {
+	validationParameter := base64.StdEncoding.EncodeToString([]byte("/Channel/Application/Endorsement"))
+
+	// Case: The defaults are set to the fields which are not set
+	definition := ChaincodeDefinition{Sequence: 3, ValidationParameter: validationParameter}
+	setChaincodeDefinitionDefaults(&definition)
+	require.Equal(t, ChaincodeDefinition{
+		Sequence:            3,
+		Version:             "3",
+		EndorsementPlugin:   "escc",
+		ValidationPlugin:    "vscc",
+		ValidationParameter: validationParameter,
+	}, definition)
+
+	// Case: The fields which are set are kept
+	definition = ChaincodeDefinition{Sequence: 3, Version: "v1.2.0", EndorsementPlugin: "custom_escc", ValidationPlugin: "custom.vscc", ValidationParameter: validationParameter}
+	setChaincodeDefinitionDefaults(&definition)
+	require.Equal(t, "v1.2.0", definition.Version)
+	require.Equal(t, "custom_escc", definition.EndorsementPlugin)
+	require.Equal(t, "custom.vscc", definition.ValidationPlugin)
+	require.NoError(t, validateChaincodeDefinition(definition))
+
+	// Case: Fail to validate the definition with the invalid version and plugins
+	invalidVersion := definition
+	invalidVersion.Version = "1.0 beta"
+	require.EqualError(t, validateChaincodeDefinition(invalidVersion), "the parameter 'ChaincodeDefinition.Version' should match ^[A-Za-z0-9_.+-]+$")
+	invalidEndorsementPlugin := definition
+	invalidEndorsementPlugin.EndorsementPlugin = "plugins/escc"
+	require.EqualError(t, validateChaincodeDefinition(invalidEndorsementPlugin), "the parameter 'ChaincodeDefinition.EndorsementPlugin' should match ^[A-Za-z0-9_.-]+$")
+	invalidValidationPlugin := definition
+	invalidValidationPlugin.ValidationPlugin = "vscc+"
+	require.EqualError(t, validateChaincodeDefinition(invalidValidationPlugin), "the parameter 'ChaincodeDefinition.ValidationPlugin' should match ^[A-Za-z0-9_.-]+$")
+
+	// Case: The defaults are set to the proposal stored before the fields are introduced
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.GetStateReturns([]byte(`{"docType": "proposal", "ID": "legacy-1", "chaincodeDefinition": {"sequence": 2, "initRequired": false, "validationParameter": "`+validationParameter+`"}, "status": "committed"}`), nil)
+	proposal, err := (&SmartContract{}).GetProposal(transactionContext, "legacy-1")
+	require.NoError(t, err)
+	require.Equal(t, "2", proposal.ChaincodeDefinition.Version)
+	require.Equal(t, DefaultEndorsementPlugin, proposal.ChaincodeDefinition.EndorsementPlugin)
+	require.Equal(t, DefaultValidationPlugin, proposal.ChaincodeDefinition.ValidationPlugin)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Functionalities to delegate the votes for chaincode update proposals to another organization.
+// The delegate votes on behalf of the delegator by calling Vote with OnBehalfOf,
+// and the delegated vote is recorded as the vote of the delegator with the delegate in the history,
+// so that it is counted in the same way as the vote made by the delegator itself.
+// The delegator can still change or retract the delegated vote, and vote by itself instead.
+
+// VoteDelegation describes a delegation of the votes from an organization that is stored as a state in the ledger.
+type VoteDelegation struct {
+	ObjectType string   `json:"docType"`                                   //docType is used to distinguish the various types of objects in state database
+	Delegator  string   `json:"delegator"`                                 // the MSP ID of the org which delegates the votes
+	Delegate   string   `json:"delegate"`                                  // the MSP ID of the org which votes on behalf of the delegator
+	ChannelIDs []string `json:"channelIDs,omitempty" metadata:",optional"` // the channels in which the delegation is available (all channels if empty)
+	Expiry     string   `json:"expiry"`                                    // the time when the delegation expires (RFC3339)
+	Time       string   `json:"time"`                                      // the time when the delegation is created (RFC3339)
+}
+
+// VoteDelegationInput represents a request input of a new vote delegation.
+type VoteDelegationInput struct {
+	Delegate   string   `json:"delegate"`
+	ChannelIDs []string `json:"channelIDs,omitempty" metadata:",optional"`
+	Expiry     string   `json:"expiry"` // RFC3339
+}
+
+// Object types
+const (
+	VoteDelegationObjectType = "voteDelegation"
+)
+
+var (
+	// ErrVoteDelegationNotFound is returned when the requested vote delegation is not found.
+	ErrVoteDelegationNotFound = fmt.Errorf("vote delegation not found")
+	// ErrNotDelegated is returned when voting on behalf of the org which does not delegate the votes to the caller.
+	ErrNotDelegated = fmt.Errorf("the votes of the org are not delegated to the caller")
+	// ErrDelegatedVoteNotAvailable is returned when the delegated vote is requested for the function other than Vote.
+	ErrDelegatedVoteNotAvailable = fmt.Errorf("the parameter 'OnBehalfOf' is only available for Vote")
+)
+
+// DelegateVotes delegates the votes of the caller's organization for chaincode update proposals to another organization until the expiry.
+// This replaces the existing delegation of the organization.
+//
+// Arguments:
+//   0: input - the request input for the vote delegation
+//
+// Returns:
+//   0: the created vote delegation
+//   1: error
+//
+func (s *SmartContract) DelegateVotes(ctx contractapi.TransactionContextInterface, input VoteDelegationInput) (*VoteDelegation, error) {
+
+	if err := s.authorize(ctx, "DelegateVotes"); err != nil {
+		return nil, err
+	}
+
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	// Validate input
+	if input.Delegate == "" {
+		return nil, fmt.Errorf("the required parameter 'Delegate' is empty")
+	}
+	if input.Delegate == mspID {
+		return nil, fmt.Errorf("the parameter 'Delegate' should not be the delegator itself")
+	}
+	for _, channelID := range input.ChannelIDs {
+		if channelID == "" {
+			return nil, fmt.Errorf("the parameter 'ChannelIDs' should not contain an empty channel ID")
+		}
+	}
+	if input.Expiry == "" {
+		return nil, fmt.Errorf("the required parameter 'Expiry' is empty")
+	}
+	if _, err := time.Parse(time.RFC3339, input.Expiry); err != nil {
+		return nil, fmt.Errorf("the parameter 'Expiry' should be RFC3339: %v", err)
+	}
+	if isOverdue(input.Expiry, txTimestamp) {
+		return nil, fmt.Errorf("the parameter 'Expiry' should be later than the current time")
+	}
+
+	delegation := &VoteDelegation{
+		ObjectType: VoteDelegationObjectType,
+		Delegator:  mspID,
+		Delegate:   input.Delegate,
+		ChannelIDs: input.ChannelIDs,
+		Expiry:     input.Expiry,
+		Time:       txTimestamp,
+	}
+	if err := s.putVoteDelegation(ctx, *delegation); err != nil {
+		return nil, err
+	}
+	return delegation, nil
+}
+
+// RevokeVoteDelegation revokes the delegation of the votes of the caller's organization.
+// The votes already made by the delegate are not affected.
+//
+// Arguments: none
+//
+// Returns:
+//   0: error
+//
+func (s *SmartContract) RevokeVoteDelegation(ctx contractapi.TransactionContextInterface) error {
+	if err := s.authorize(ctx, "RevokeVoteDelegation"); err != nil {
+		return err
+	}
+
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	if _, err := s.GetVoteDelegation(ctx, mspID); err != nil {
+		return err
+	}
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(VoteDelegationObjectType, []string{mspID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for vote delegation: %v", err)
+	}
+	if err := ctx.GetStub().DelState(compositeKey); err != nil {
+		return fmt.Errorf("error happened deleting the vote delegation: %v", err)
+	}
+	return nil
+}
+
+// GetVoteDelegation returns the vote delegation of the given organization (including the expired one).
+//
+// Arguments:
+//   0: delegator - the MSP ID of the org which delegates the votes
+//
+// Returns:
+//   0: the vote delegation of the org
+//   1: error
+//
+func (s *SmartContract) GetVoteDelegation(ctx contractapi.TransactionContextInterface, delegator string) (*VoteDelegation, error) {
+	if delegator == "" {
+		return nil, fmt.Errorf("the required parameter 'delegator' is empty")
+	}
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(VoteDelegationObjectType, []string{delegator})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for vote delegation: %v", err)
+	}
+	delegationJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading vote delegation of %s: %v", delegator, err)
+	}
+	if delegationJSON == nil {
+		return nil, ErrVoteDelegationNotFound
+	}
+
+	var delegation VoteDelegation
+	if err = json.Unmarshal(delegationJSON, &delegation); err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling a vote delegation JSON representation to struct: %v", err)
+	}
+	return &delegation, nil
+}
+
+// GetAllVoteDelegations returns all the vote delegations (including the expired ones).
+//
+// Arguments: none
+//
+// Returns:
+//   0: the vote delegations (ordered by the delegator)
+//   1: error
+//
+func (s *SmartContract) GetAllVoteDelegations(ctx contractapi.TransactionContextInterface) ([]*VoteDelegation, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(VoteDelegationObjectType, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	delegations := []*VoteDelegation{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available vote delegations: %v", err)
+		}
+		var delegation VoteDelegation
+		if err = json.Unmarshal(result.Value, &delegation); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a vote delegation JSON representation to struct: %v", err)
+		}
+		delegations = append(delegations, &delegation)
+	}
+	sort.SliceStable(delegations, func(i, j int) bool {
+		return delegations[i].Delegator < delegations[j].Delegator
+	})
+	return delegations, nil
+}
+
+// -- Internal logics
+
+// delegateHistory changes the vote made by the caller to the vote on behalf of the delegator,
+// after checking that the delegator delegates the votes for the proposal to the caller.
+func (s *SmartContract) delegateHistory(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, history *History, delegator string) error {
+	delegation, err := s.GetVoteDelegation(ctx, delegator)
+	if err == ErrVoteDelegationNotFound {
+		return ErrNotDelegated
+	}
+	if err != nil {
+		return err
+	}
+	if delegation.Delegate != history.OrgID {
+		return ErrNotDelegated
+	}
+	if isOverdue(delegation.Expiry, history.Time) {
+		return fmt.Errorf("the vote delegation of %s has expired", delegator)
+	}
+	if len(delegation.ChannelIDs) > 0 && !contains(delegation.ChannelIDs, proposal.ChannelID) {
+		return fmt.Errorf("the vote delegation of %s is not available for the channel %s", delegator, proposal.ChannelID)
+	}
+
+	history.Delegate = history.OrgID
+	history.OrgID = delegator
+	return nil
+}
+
+func (s *SmartContract) putVoteDelegation(ctx contractapi.TransactionContextInterface, delegation VoteDelegation) error {
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(VoteDelegationObjectType, []string{delegation.Delegator})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for vote delegation: %v", err)
+	}
+	delegationJSON, err := json.Marshal(delegation)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the vote delegation: %v", err)
+	}
+	if err := ctx.GetStub().PutState(compositeKey, delegationJSON); err != nil {
+		return fmt.Errorf("error happened persisting the vote delegation on the ledger: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDelegateVotes(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+	expiry := now.Add(time.Hour).Format(time.RFC3339)
+
+	// Case: Delegate the votes to Org2
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	delegation, err := sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org2MSP", ChannelIDs: []string{"mychannel"}, Expiry: expiry})
+	require.NoError(t, err)
+	expected := &VoteDelegation{
+		ObjectType: VoteDelegationObjectType,
+		Delegator:  "Org1MSP",
+		Delegate:   "Org2MSP",
+		ChannelIDs: []string{"mychannel"},
+		Expiry:     expiry,
+		Time:       now.UTC().Format(time.RFC3339),
+	}
+	require.Equal(t, expected, delegation)
+	delegation, err = sc.GetVoteDelegation(transactionContext, "Org1MSP")
+	require.NoError(t, err)
+	require.Equal(t, expected, delegation)
+
+	// Case: Replace the delegation with the one to Org3
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org3MSP", Expiry: expiry})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org1MSP", Expiry: expiry})
+	require.NoError(t, err)
+	delegations, err := sc.GetAllVoteDelegations(transactionContext)
+	require.NoError(t, err)
+	require.Len(t, delegations, 2)
+	require.Equal(t, "Org1MSP", delegations[0].Delegator)
+	require.Equal(t, "Org3MSP", delegations[0].Delegate)
+	require.Empty(t, delegations[0].ChannelIDs)
+	require.Equal(t, "Org2MSP", delegations[1].Delegator)
+
+	// Case: Revoke the delegation by the delegator
+	require.NoError(t, sc.RevokeVoteDelegation(transactionContext))
+	_, err = sc.GetVoteDelegation(transactionContext, "Org2MSP")
+	require.ErrorIs(t, err, ErrVoteDelegationNotFound)
+	err = sc.RevokeVoteDelegation(transactionContext)
+	require.ErrorIs(t, err, ErrVoteDelegationNotFound)
+
+	// Case: Fail with invalid input
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Expiry: expiry})
+	require.EqualError(t, err, "the required parameter 'Delegate' is empty")
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org2MSP", Expiry: expiry})
+	require.EqualError(t, err, "the parameter 'Delegate' should not be the delegator itself")
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org1MSP", ChannelIDs: []string{""}, Expiry: expiry})
+	require.EqualError(t, err, "the parameter 'ChannelIDs' should not contain an empty channel ID")
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org1MSP"})
+	require.EqualError(t, err, "the required parameter 'Expiry' is empty")
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org1MSP", Expiry: "2026-01-01"})
+	require.EqualError(t, err, `the parameter 'Expiry' should be RFC3339: parsing time "2026-01-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`)
+	_, err = sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org1MSP", Expiry: now.UTC().Format(time.RFC3339)})
+	require.EqualError(t, err, "the parameter 'Expiry' should be later than the current time")
+	_, err = sc.GetVoteDelegation(transactionContext, "")
+	require.EqualError(t, err, "the required parameter 'delegator' is empty")
+}
+
+func TestVoteOnBehalfOf(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+	newKeyHistory(chaincodeStub)
+
+	sc := &SmartContract{}
+	expiry := now.Add(time.Hour).Format(time.RFC3339)
+
+	requestProposal := func(proposalID string, channelID string) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChannelID = channelID
+		input.ChaincodeName = proposalID
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+	}
+	voteOnBehalfOf := func(proposalID string, delegator string) error {
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		return sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID, OnBehalfOf: delegator})
+	}
+
+	// Prepare: Org3 delegates its votes in mychannel to Org2
+	chaincodeStub.GetCreatorReturns(org3MSP, nil)
+	_, err := sc.DelegateVotes(transactionContext, VoteDelegationInput{Delegate: "Org2MSP", ChannelIDs: []string{"mychannel"}, Expiry: expiry})
+	require.NoError(t, err)
+	requestProposal("request-1", "mychannel")
+
+	// Case: Fail to vote on behalf of the org which does not delegate the votes
+	err = voteOnBehalfOf("request-1", "Org4MSP")
+	require.ErrorIs(t, err, ErrNotDelegated)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", OnBehalfOf: "Org3MSP"})
+	require.ErrorIs(t, err, ErrNotDelegated)
+
+	// Case: The delegated vote is counted as the vote of the delegator
+	chaincodeStub.GetTxIDReturns("tx-delegated")
+	require.NoError(t, voteOnBehalfOf("request-1", "Org3MSP"))
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, proposal.Status)
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "request-1", TaskID: Vote})
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	for _, history := range histories {
+		if history.OrgID == "Org3MSP" {
+			require.Equal(t, "Org2MSP", history.Delegate)
+		} else {
+			require.Empty(t, history.Delegate)
+		}
+	}
+
+	// Case: The delegate is the submitter of the delegated vote in the audit trail
+	records, err := sc.GetProposalAuditTrail(transactionContext, "request-1")
+	require.NoError(t, err)
+	delegatedRecords := 0
+	for _, record := range records {
+		if record.TxID == "tx-delegated" && record.ObjectType == HistoryObjectType {
+			require.Equal(t, "Org3MSP", record.OrgID)
+			require.Equal(t, "Org2MSP", record.Submitter)
+			delegatedRecords++
+		}
+	}
+	require.Equal(t, 1, delegatedRecords)
+
+	// Case: Fail to vote out of the scope of the delegation
+	requestProposal("request-2", "otherchannel")
+	err = voteOnBehalfOf("request-2", "Org3MSP")
+	require.EqualError(t, err, "the vote delegation of Org3MSP is not available for the channel otherchannel")
+
+	// Case: Fail to vote twice for the delegator
+	requestProposal("request-3", "mychannel")
+	chaincodeStub.GetCreatorReturns(org3MSP, nil)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-3", Status: Disagreed}))
+	err = voteOnBehalfOf("request-3", "Org3MSP")
+	require.EqualError(t, err, "failed to put the history: the state is already exists: Org3MSP")
+
+	// Case: Fail to vote after the delegation expires
+	requestProposal("request-4", "mychannel")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now.Add(2*time.Hour)), nil)
+	err = voteOnBehalfOf("request-4", "Org3MSP")
+	require.EqualError(t, err, "the vote delegation of Org3MSP has expired")
+
+	// Case: Fail to vote after the delegation is revoked
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	chaincodeStub.GetCreatorReturns(org3MSP, nil)
+	require.NoError(t, sc.RevokeVoteDelegation(transactionContext))
+	err = voteOnBehalfOf("request-4", "Org3MSP")
+	require.ErrorIs(t, err, ErrNotDelegated)
+
+	// Case: The delegated vote is only available for Vote
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-4", Status: Disagreed, OnBehalfOf: "Org3MSP"})
+	require.ErrorIs(t, err, ErrDelegatedVoteNotAvailable)
+	err = sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-1", OnBehalfOf: "Org3MSP"})
+	require.ErrorIs(t, err, ErrDelegatedVoteNotAvailable)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-1", OnBehalfOf: "Org3MSP"})
+	require.ErrorIs(t, err, ErrDelegatedVoteNotAvailable)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric/common/util"
+)
+
+// ChannelOpsDependencyPrefix is the prefix of the dependencies which refer to channel update proposals managed by channel-ops.
+// The dependencies without the prefix refer to chaincode update proposals.
+const ChannelOpsDependencyPrefix = "channel-ops:"
+
+// ReleaseDependentProposals releases the waiting proposals whose dependencies have all been committed.
+// This changes the status of the proposals from waiting to approved, so that the agents start to prepare the deployment.
+// The released proposals whose deployment window has not opened yet are changed to scheduled,
+// and the ones whose deployment window has closed are changed to expired instead.
+// The waiting proposals which depend on a proposal that can no longer be committed
+// (i.e., rejected, withdrawn or expired) are changed to rejected.
+// The ones which depend on a failed proposal keep waiting, since the failed proposal can be retried.
+// This is expected to be called by the agents after each commit of a chaincode or a channel update,
+// and after each proposal is decided against.
+//
+// Arguments: none
+//
+// Returns:
+//   0: the list of the IDs of the released proposals which are approved
+//   1: error
+//
+// Events:
+//   (if one or more proposals are released, expired or rejected)
+//   name: releasedEvent
+//   payload: ReleasedEventDetail
+//   (NOTE: a transaction can only have one chaincode event, so the event does not have the proposal ID suffix)
+//
+func (s *SmartContract) ReleaseDependentProposals(ctx contractapi.TransactionContextInterface) ([]string, error) {
+
+	if err := s.authorize(ctx, "ReleaseDependentProposals"); err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.getProposalsByStatus(ctx, Waiting)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposals: %v", err)
+	}
+
+	eventDetail := ReleasedEventDetail{
+		Deployments:      []DeploymentEventDetail{},
+		ExpiredProposals: []string{},
+	}
+	releasedIDs := []string{}
+	// The statuses updated in this transaction (the states put in the current transaction cannot be read in it).
+	// The loop is repeated while any proposal is updated, so that the rejection is propagated to the proposals depending on it.
+	updated := map[string]string{}
+	for repeat := true; repeat; {
+		repeat = false
+		for _, proposal := range proposals {
+			if _, ok := updated[proposal.ID]; ok {
+				continue
+			}
+			committed, err := s.areDependenciesCommitted(ctx, *proposal, updated)
+			if err == errDependencyNotCommittable {
+				proposal.Status = Rejected
+				if err := s.putProposal(ctx, proposal); err != nil {
+					return nil, fmt.Errorf("failed to update the status: %v", err)
+				}
+				eventDetail.RejectedProposals = append(eventDetail.RejectedProposals, proposal.ID)
+				updated[proposal.ID] = Rejected
+				repeat = true
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !committed {
+				continue
+			}
+			status, err := s.releaseProposal(ctx, *proposal, &eventDetail)
+			if err != nil {
+				return nil, err
+			}
+			if status == Approved {
+				releasedIDs = append(releasedIDs, proposal.ID)
+			}
+			updated[proposal.ID] = status
+			repeat = repeat || isNotCommittable(status)
+		}
+	}
+	if err := s.setReleasedEvent(ctx, eventDetail); err != nil {
+		return nil, err
+	}
+	return releasedIDs, nil
+}
+
+// -- Internal logics
+
+// validateDependencies validates the dependencies of the proposal with the given ID.
+// Each dependency should refer to an existing proposal other than the proposal itself.
+func (s *SmartContract) validateDependencies(ctx contractapi.TransactionContextInterface, proposalID string, dependsOn []string) error {
+	dependencies := map[string]bool{}
+	for _, dependency := range dependsOn {
+		if dependency == "" || dependency == ChannelOpsDependencyPrefix {
+			return fmt.Errorf("the parameter 'DependsOn' should not contain an empty proposal ID")
+		}
+		if dependency == proposalID {
+			return fmt.Errorf("the parameter 'DependsOn' should not contain the proposal itself")
+		}
+		if dependencies[dependency] {
+			return fmt.Errorf("the proposal %s is duplicated in the parameter 'DependsOn'", dependency)
+		}
+		dependencies[dependency] = true
+
+		if _, err := s.getDependencyStatus(ctx, dependency); err != nil {
+			return fmt.Errorf("the dependency %s is not found: %v", dependency, err)
+		}
+	}
+	return nil
+}
+
+// getDependencyStatus returns the status of the proposal referred to by the dependency.
+func (s *SmartContract) getDependencyStatus(ctx contractapi.TransactionContextInterface, dependency string) (string, error) {
+	if !strings.HasPrefix(dependency, ChannelOpsDependencyPrefix) {
+		proposal, err := s.getProposalOrArchived(ctx, dependency)
+		if err != nil {
+			return "", err
+		}
+		return proposal.Status, nil
+	}
+
+	channelOpsArgs := util.ToChaincodeArgs("GetProposal", strings.TrimPrefix(dependency, ChannelOpsDependencyPrefix))
+	response := ctx.GetStub().InvokeChaincode(channelOpsCCName(), channelOpsArgs, "")
+	if response.Status != shim.OK {
+		return "", fmt.Errorf("failed to call get proposal (code: %d, message: %v)",
+			response.Status, response.Message)
+	}
+	var channelUpdateProposal struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(response.Payload, &channelUpdateProposal); err != nil {
+		return "", fmt.Errorf("error happened unmarshalling the channel update proposal: %v", err)
+	}
+	return channelUpdateProposal.Status, nil
+}
+
+// errDependencyNotCommittable is returned when any dependency of the proposal can no longer be committed.
+var errDependencyNotCommittable = fmt.Errorf("the dependency can no longer be committed")
+
+// areDependenciesCommitted returns whether all the dependencies of the proposal have been committed.
+// (the status of the committed channel update proposals is also "committed")
+// If updated is not nil, the statuses in it are used instead of the ones in stateDB.
+// This returns errDependencyNotCommittable if any dependency can no longer be committed.
+func (s *SmartContract) areDependenciesCommitted(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, updated map[string]string) (bool, error) {
+	committed := true
+	for _, dependency := range proposal.DependsOn {
+		status, ok := updated[dependency]
+		if !ok {
+			var err error
+			if status, err = s.getDependencyStatus(ctx, dependency); err != nil {
+				return false, fmt.Errorf("failed to get the status of the dependency %s: %v", dependency, err)
+			}
+		}
+		if isNotCommittable(status) {
+			return false, errDependencyNotCommittable
+		}
+		if status != Committed {
+			committed = false
+		}
+	}
+	return committed, nil
+}
+
+// isNotCommittable returns whether the proposal with the status can no longer be committed.
+// (the failed proposals are not included, since they can be retried)
+func isNotCommittable(status string) bool {
+	return status == Rejected || status == Withdrawn || status == Expired
+}
+
+func (s *SmartContract) updateStatusToWaiting(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	proposal.Status = Waiting
+
+	// Put proposal to stateDB
+	if err := s.putProposal(ctx, &proposal); err != nil {
+		return err
+	}
+
+	// -- Set Event
+	return s.setEvent(ctx, WaitingEvent, proposal.ID, proposal)
+}
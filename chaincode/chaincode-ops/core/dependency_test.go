@@ -0,0 +1,281 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// invokeChaincodeWithChannelUpdates returns a dummy implementation for chaincode to chaincode,
+// which also returns the channel update proposals with the given statuses.
+func invokeChaincodeWithChannelUpdates(statuses map[string]string) func(string, [][]byte, string) peer.Response {
+	return func(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
+		if string(arg2[0]) != "GetProposal" {
+			return invokeChaincode(arg1, arg2, arg3)
+		}
+		proposalID := string(arg2[1])
+		status, ok := statuses[proposalID]
+		if !ok {
+			return peer.Response{Status: shim.ERROR, Message: "proposal not found"}
+		}
+		proposalJSON, err := json.Marshal(map[string]string{"ID": proposalID, "status": status})
+		if err != nil {
+			panic(err)
+		}
+		return peer.Response{Status: shim.OK, Payload: proposalJSON}
+	}
+}
+
+func TestProposalDependencies(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	channelUpdates := map[string]string{
+		"update-channel": Approved,
+		"update-later":   Approved,
+		"update-pending": "proposed",
+		"update-failed":  Failed,
+	}
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithChannelUpdates(channelUpdates)
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	requestProposal := func(proposalID string, dependsOn ...string) (*ChaincodeUpdateProposal, error) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = proposalID
+		input.DependsOn = dependsOn
+		return sc.RequestProposal(transactionContext, input)
+	}
+	vote := func(proposalID string) {
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+	}
+	requireStatus := func(proposalID string, status string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, proposal.Status)
+	}
+
+	// Case: The approved proposal waits for the dependencies to be committed
+	_, err := requestProposal("deploy-lib")
+	require.NoError(t, err)
+	proposal, err := requestProposal("deploy-app", "deploy-lib", "channel-ops:update-channel")
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy-lib", "channel-ops:update-channel"}, proposal.DependsOn)
+	vote("deploy-app")
+	requireStatus("deploy-app", Waiting)
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "waitingEvent.deploy-app", eventName)
+	var waitingProposal ChaincodeUpdateProposal
+	require.NoError(t, json.Unmarshal(eventPayload, &waitingProposal))
+	require.Equal(t, []string{"deploy-lib", "channel-ops:update-channel"}, waitingProposal.DependsOn)
+
+	// Case: Nothing is released while the dependencies are not committed
+	setEventCallCount := chaincodeStub.SetEventCallCount()
+	releasedIDs, err := sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	require.Equal(t, setEventCallCount, chaincodeStub.SetEventCallCount())
+
+	// Case: The waiting proposal blocks another proposal for the same chaincode
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	input.ID = "deploy-app-another"
+	input.ChaincodeName = "deploy-app"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the chaincode already has an open proposal (deploy-app)")
+
+	// Case: The proposal keeps waiting until all the dependencies are committed
+	vote("deploy-lib")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "deploy-lib"}))
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "deploy-lib"}))
+	require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: "deploy-lib"}))
+	requireStatus("deploy-lib", Committed)
+	releasedIDs, err = sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	requireStatus("deploy-app", Waiting)
+
+	// Case: The proposal is released after all the dependencies are committed
+	channelUpdates["update-channel"] = Committed
+	releasedIDs, err = sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, []string{"deploy-app"}, releasedIDs)
+	requireStatus("deploy-app", Approved)
+	eventName, eventPayload = lastEvent(chaincodeStub)
+	require.Equal(t, "releasedEvent", eventName)
+	var eventDetail ReleasedEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Len(t, eventDetail.Deployments, 1)
+	require.Equal(t, "deploy-app", eventDetail.Deployments[0].Proposal.ID)
+	require.Equal(t, []string{"Org1MSP", "Org2MSP"}, eventDetail.Deployments[0].OperationTargets)
+
+	// Case: The proposal whose dependencies are already committed is approved immediately
+	_, err = requestProposal("deploy-next", "deploy-lib", "channel-ops:update-channel")
+	require.NoError(t, err)
+	vote("deploy-next")
+	requireStatus("deploy-next", Approved)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "prepareToDeployEvent.deploy-next", eventName)
+
+	// Case: The released proposal is scheduled if its deployment window has not opened yet
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input = baseProposalAndInput("")
+	input.ID = "deploy-later"
+	input.ChaincodeName = "deploy-later"
+	input.NotBefore = now.Add(time.Hour).Format(time.RFC3339)
+	input.DependsOn = []string{"channel-ops:update-later"}
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	vote("deploy-later")
+	requireStatus("deploy-later", Waiting)
+	channelUpdates["update-later"] = Committed
+	releasedIDs, err = sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	requireStatus("deploy-later", Scheduled)
+	_, eventPayload = lastEvent(chaincodeStub)
+	eventDetail = ReleasedEventDetail{}
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Empty(t, eventDetail.Deployments)
+	require.Equal(t, []string{"deploy-later"}, eventDetail.ScheduledProposals)
+
+	// Case: The proposer can withdraw the waiting proposal
+	_, err = requestProposal("deploy-withdrawn", "channel-ops:update-pending")
+	require.NoError(t, err)
+	vote("deploy-withdrawn")
+	requireStatus("deploy-withdrawn", Waiting)
+	_, err = requestProposal("deploy-chain-1", "deploy-withdrawn")
+	require.NoError(t, err)
+	vote("deploy-chain-1")
+	_, err = requestProposal("deploy-chain-2", "deploy-chain-1")
+	require.NoError(t, err)
+	vote("deploy-chain-2")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.WithdrawProposal(transactionContext, "deploy-withdrawn"))
+	requireStatus("deploy-withdrawn", Withdrawn)
+
+	// Case: The waiting proposals are rejected if their dependencies can no longer be committed (including the indirect ones)
+	releasedIDs, err = sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	requireStatus("deploy-chain-1", Rejected)
+	requireStatus("deploy-chain-2", Rejected)
+	eventName, eventPayload = lastEvent(chaincodeStub)
+	require.Equal(t, "releasedEvent", eventName)
+	eventDetail = ReleasedEventDetail{}
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Empty(t, eventDetail.Deployments)
+	require.Equal(t, []string{"deploy-chain-1", "deploy-chain-2"}, eventDetail.RejectedProposals)
+
+	// Case: The proposal is rejected on approval if its dependencies can no longer be committed
+	_, err = requestProposal("deploy-after-withdrawn", "deploy-withdrawn")
+	require.NoError(t, err)
+	vote("deploy-after-withdrawn")
+	requireStatus("deploy-after-withdrawn", Rejected)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "rejectedEvent.deploy-after-withdrawn", eventName)
+
+	// Case: The proposal keeps waiting if its dependency is failed, since the failed proposal can be retried
+	_, err = requestProposal("deploy-after-failed", "channel-ops:update-failed")
+	require.NoError(t, err)
+	vote("deploy-after-failed")
+	requireStatus("deploy-after-failed", Waiting)
+	releasedIDs, err = sc.ReleaseDependentProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	requireStatus("deploy-after-failed", Waiting)
+}
+
+func TestRequestProposalWithInvalidDependencies(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithChannelUpdates(map[string]string{"update-channel": Committed})
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	tests := []struct {
+		name      string
+		dependsOn []string
+		errMsg    string
+	}{
+		{
+			name:      "empty proposal ID",
+			dependsOn: []string{""},
+			errMsg:    "the parameter 'DependsOn' should not contain an empty proposal ID",
+		},
+		{
+			name:      "empty channel update proposal ID",
+			dependsOn: []string{"channel-ops:"},
+			errMsg:    "the parameter 'DependsOn' should not contain an empty proposal ID",
+		},
+		{
+			name:      "self reference",
+			dependsOn: []string{"deploy-app"},
+			errMsg:    "the parameter 'DependsOn' should not contain the proposal itself",
+		},
+		{
+			name:      "duplicated dependency",
+			dependsOn: []string{"channel-ops:update-channel", "channel-ops:update-channel"},
+			errMsg:    "the proposal channel-ops:update-channel is duplicated in the parameter 'DependsOn'",
+		},
+		{
+			name:      "unknown chaincode update proposal",
+			dependsOn: []string{"deploy-unknown"},
+			errMsg:    "the dependency deploy-unknown is not found: proposal not found",
+		},
+		{
+			name:      "unknown channel update proposal",
+			dependsOn: []string{"channel-ops:update-unknown"},
+			errMsg:    "the dependency channel-ops:update-unknown is not found: failed to call get proposal (code: 500, message: proposal not found)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, input := baseProposalAndInput("")
+			input.ID = "deploy-app"
+			input.ChaincodeName = "app"
+			input.DependsOn = tt.dependsOn
+			_, err := sc.RequestProposal(transactionContext, input)
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+
+	// Case: The members of a release cannot have dependencies
+	_, member1 := baseProposalAndInput("")
+	member1.ID = "deploy-app"
+	member1.ChaincodeName = "app"
+	member1.DependsOn = []string{"channel-ops:update-channel"}
+	_, member2 := baseProposalAndInput("")
+	member2.ID = "deploy-lib"
+	member2.ChaincodeName = "lib"
+	_, err := sc.RequestReleaseProposal(transactionContext, ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member1, member2}})
+	require.EqualError(t, err, "the parameter 'Members[0].DependsOn' is not available for the members of a release")
+}
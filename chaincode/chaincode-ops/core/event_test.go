@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/events"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEventEnvelope(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxIDReturns("tx-1")
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// Case: The event bound to a proposal has the proposal ID and the proposal as the data
+	_, input := baseProposalAndInput("")
+	_, err := sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(chaincodeStub.SetEventCallCount() - 1)
+	require.Equal(t, "newProposalEvent.request-1", eventName)
+	envelope, err := events.Decode(eventPayload)
+	require.NoError(t, err)
+	require.Equal(t, events.SpecVersion, envelope.SpecVersion)
+	require.Equal(t, NewProposalEvent, envelope.Type)
+	require.Equal(t, events.ChaincodeOpsSource, envelope.Source)
+	require.Equal(t, "request-1", envelope.ProposalID)
+	require.Equal(t, "ops-channel", envelope.ChannelID)
+	require.Equal(t, "Org1MSP", envelope.Actor)
+	require.Equal(t, "tx-1", envelope.TxID)
+	require.Equal(t, now.UTC().Format(time.RFC3339), envelope.Time)
+	var proposal ChaincodeUpdateProposal
+	require.NoError(t, envelope.DecodeData(&proposal))
+	require.Equal(t, "request-1", proposal.ID)
+	require.Equal(t, Proposed, proposal.Status)
+
+	// Case: The event without data has no data in the envelope
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Disagreed}))
+	eventName, eventPayload = chaincodeStub.SetEventArgsForCall(chaincodeStub.SetEventCallCount() - 1)
+	require.Equal(t, "rejectedEvent.request-1", eventName)
+	envelope, err = events.Decode(eventPayload)
+	require.NoError(t, err)
+	require.Equal(t, "Org2MSP", envelope.Actor)
+	require.ErrorIs(t, envelope.DecodeData(&proposal), events.ErrNoData)
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Functionalities to change the voting config through the same vote flow as chaincode update proposals.
+// Changes to the voting config affect how every chaincode update proposal is decided,
+// so they are decided by a majority of all organizations in the ops channel regardless of the current voting config.
+
+// GovernanceProposal describes a proposal to change the voting config that is stored as a state in the ledger.
+type GovernanceProposal struct {
+	ObjectType   string       `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	ID           string       `json:"ID"`
+	Creator      string       `json:"creator"`
+	Action       string       `json:"action"`
+	VotingConfig VotingConfig `json:"votingConfig"`
+	Status       string       `json:"status"`
+	Time         string       `json:"time"`
+}
+
+// GovernanceProposalInput represents a request input of a new governance proposal.
+type GovernanceProposalInput struct {
+	ID           string       `json:"ID"`
+	Action       string       `json:"action,omitempty" metadata:",optional"`
+	VotingConfig VotingConfig `json:"votingConfig,omitempty" metadata:",optional"`
+}
+
+// Object types
+const (
+	GovernanceProposalObjectType = "governanceProposal"
+)
+
+// Chaincode event names for governance proposals
+const (
+	NewGovernanceProposalEvent = "newGovernanceProposalEvent"
+	NewGovernanceVoteEvent     = "newGovernanceVoteEvent"
+	GovernanceCommittedEvent   = "governanceCommittedEvent"
+	GovernanceRejectedEvent    = "governanceRejectedEvent"
+	GovernanceWithdrawnEvent   = "governanceWithdrawnEvent"
+)
+
+// Task IDs for governance proposals
+const (
+	GovernanceVote = "governanceVote"
+)
+
+// Action types for governance proposals
+const (
+	SetVotingConfigAction   = "set"
+	UnsetVotingConfigAction = "unset"
+)
+
+var (
+	// ErrVotingConfigAlreadySet is returned when the voting config is directly set after the bootstrap.
+	ErrVotingConfigAlreadySet = fmt.Errorf("voting config is already set (use a governance proposal to change it)")
+)
+
+// RequestGovernanceProposal requests a new governance proposal to change the voting config.
+//
+// Arguments:
+//   0: input - the request input for the governance proposal
+//
+// Returns:
+//   0: the created governance proposal
+//   1: error
+//
+// Events:
+//   (if the request can be approved without any other votes)
+//   name: governanceCommittedEvent(<proposalID>)
+//   payload: the committed governance proposal
+//   (else)
+//   name: newGovernanceProposalEvent(<proposalID>)
+//   payload: the created governance proposal
+//
+func (s *SmartContract) RequestGovernanceProposal(ctx contractapi.TransactionContextInterface, input GovernanceProposalInput) (*GovernanceProposal, error) {
+
+	if err := s.authorize(ctx, "RequestGovernanceProposal"); err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	if input.Action == "" {
+		input.Action = SetVotingConfigAction
+	}
+
+	// Validate input
+	if input.ID == "" {
+		return nil, fmt.Errorf("the required parameter proposal 'ID' is empty")
+	}
+
+	switch input.Action {
+	case SetVotingConfigAction:
+		if err := validateVotingConfig(input.VotingConfig); err != nil {
+			return nil, err
+		}
+	case UnsetVotingConfigAction:
+		input.VotingConfig = VotingConfig{ChannelID: input.VotingConfig.ChannelID}
+	default:
+		return nil, fmt.Errorf("incorrect action type - expecting %s or %s", SetVotingConfigAction, UnsetVotingConfigAction)
+	}
+	input.VotingConfig.ObjectType = VotingConfigObjectType
+
+	// Build the proposal
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	proposal := GovernanceProposal{
+		ObjectType:   GovernanceProposalObjectType,
+		ID:           input.ID,
+		Creator:      mspID,
+		Action:       input.Action,
+		VotingConfig: input.VotingConfig,
+		Status:       Proposed,
+		Time:         txTimestamp,
+	}
+
+	// Fail if the proposal with the ID already exists
+	if p, _ := s.GetGovernanceProposal(ctx, input.ID); p != nil {
+		return nil, ErrProposalIDAreadyInUse
+	}
+
+	// Put the proposal to stateDB
+	if err = s.putGovernanceProposal(ctx, proposal); err != nil {
+		return nil, fmt.Errorf("failed to put the proposal: %v", err)
+	}
+
+	// Vote for myself
+	history, err := s.putHistory(ctx, proposal.ID, GovernanceVote, Agreed, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put the history that the org votes for: %v", err)
+	}
+
+	// If the vote from this organization alone meets the criteria, apply the voting config immediately
+	votePassed, err := s.meetCriteria(ctx, *history, SimpleMajority, false, ctx.GetStub().GetChannelID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to do meetCriteria: %v", err)
+	}
+	if votePassed {
+		if err = s.commitGovernanceProposal(ctx, &proposal); err != nil {
+			return nil, fmt.Errorf("failed to update the status: %v", err)
+		}
+		return &proposal, nil
+	}
+
+	// Else issue NewGovernanceProposalEvent
+	if err = s.setGovernanceEvent(ctx, NewGovernanceProposalEvent, proposal); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// VoteForGovernanceProposal votes for / against the governance proposal.
+// This function records the vote as a state into the ledger.
+// Also, if the proposal is voted by a majority of the organizations in the ops channel,
+// this applies the proposed voting config and changes the status of the proposal from proposed to committed.
+//
+// Arguments:
+//   0: taskStatusUpdateRequest - the request input for voting for/against the governance proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   (if the status is changed to committed)
+//   name: governanceCommittedEvent(<proposalID>)
+//   payload: the committed governance proposal
+//   (if the status is changed to rejected)
+//   name: governanceRejectedEvent(<proposalID>)
+//   payload: the rejected governance proposal
+//   (else)
+//   name: newGovernanceVoteEvent(<proposalID>)
+//   payload: nil
+//
+func (s *SmartContract) VoteForGovernanceProposal(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
+
+	if err := s.authorize(ctx, "VoteForGovernanceProposal"); err != nil {
+		return err
+	}
+
+	// Set default values
+	if taskStatusUpdateRequest.Status == "" {
+		taskStatusUpdateRequest.Status = Agreed
+	}
+
+	// Validate input
+	if taskStatusUpdateRequest.ProposalID == "" {
+		return fmt.Errorf("the required parameter 'ProposalID' is empty")
+	}
+
+	if taskStatusUpdateRequest.Status != Agreed && taskStatusUpdateRequest.Status != Disagreed {
+		return fmt.Errorf("task status for vote should be %s or %s", Agreed, Disagreed)
+	}
+
+	if taskStatusUpdateRequest.OnBehalfOf != "" {
+		return ErrDelegatedVoteNotAvailable
+	}
+
+	// Get proposal from StateDB
+	proposal, err := s.GetGovernanceProposal(ctx, taskStatusUpdateRequest.ProposalID)
+	if err != nil {
+		return fmt.Errorf("failed to get the proposal: %v", err)
+	}
+	// If the proposal status already got changed from "Proposed", return error
+	if proposal.Status != Proposed {
+		return fmt.Errorf("the voting is already closed")
+	}
+
+	// Put the task status as a history to stateDB
+	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, GovernanceVote, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, false)
+	if err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
+	}
+
+	// [State Transition]
+	// The same as Vote() for chaincode update proposals except that "Approved" proposals are committed immediately
+	switch taskStatusUpdateRequest.Status {
+	case Agreed:
+		votePassed, err := s.meetCriteria(ctx, *history, SimpleMajority, false, ctx.GetStub().GetChannelID())
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if votePassed {
+			if err = s.commitGovernanceProposal(ctx, proposal); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return nil
+		}
+	case Disagreed:
+		voteRejected, err := s.meetCriteria(ctx, *history, SimpleMajority, true, ctx.GetStub().GetChannelID())
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if voteRejected {
+			proposal.Status = Rejected
+			if err = s.putGovernanceProposal(ctx, *proposal); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return s.setGovernanceEvent(ctx, GovernanceRejectedEvent, *proposal)
+		}
+	}
+	return s.setEvent(ctx, NewGovernanceVoteEvent, proposal.ID, nil)
+}
+
+// WithdrawGovernanceProposal withdraws the governance proposal.
+// This only accepts the request from the proposing organization.
+// This function is only available before the decision of the proposal.
+//
+// Arguments:
+//   0: proposalID - the ID for the governance proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   name: governanceWithdrawnEvent(<proposalID>)
+//   payload: the withdrawn governance proposal
+//
+func (s *SmartContract) WithdrawGovernanceProposal(ctx contractapi.TransactionContextInterface, proposalID string) error {
+
+	if err := s.authorize(ctx, "WithdrawGovernanceProposal"); err != nil {
+		return err
+	}
+
+	// Validate input
+	if proposalID == "" {
+		return fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	// Get proposal from StateDB
+	proposal, err := s.GetGovernanceProposal(ctx, proposalID)
+	if err != nil {
+		return ErrProposalNotFound
+	}
+
+	// If the proposal status already got changed from "Proposed", return error
+	if proposal.Status != Proposed {
+		return fmt.Errorf("the voting is already closed")
+	}
+
+	// If the proposal is not created by the requester, return error
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	if proposal.Creator != mspID {
+		return fmt.Errorf("only the proposer (%v) can withdraw the proposal", proposal.Creator)
+	}
+
+	proposal.Status = Withdrawn
+	if err = s.putGovernanceProposal(ctx, *proposal); err != nil {
+		return fmt.Errorf("failed to update the status: %v", err)
+	}
+	return s.setGovernanceEvent(ctx, GovernanceWithdrawnEvent, *proposal)
+}
+
+// GetGovernanceProposal returns the governance proposal with the given ID.
+//
+// Arguments:
+//   0: proposalID - the ID of the governance proposal
+//
+// Returns:
+//   0: the governance proposal with the given ID
+//   1: error
+//
+func (s *SmartContract) GetGovernanceProposal(ctx contractapi.TransactionContextInterface, proposalID string) (*GovernanceProposal, error) {
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(GovernanceProposalObjectType, []string{proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for proposal: %v", err)
+	}
+
+	proposalJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading proposal with id (%v): %v", proposalID, err)
+	}
+
+	if proposalJSON == nil {
+		return nil, ErrProposalNotFound
+	}
+
+	var proposal GovernanceProposal
+	err = json.Unmarshal(proposalJSON, &proposal)
+	if err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+	}
+	return &proposal, nil
+}
+
+// GetAllGovernanceProposals returns the all governance proposals.
+//
+// Arguments: none
+//
+// Returns:
+//   0: the map of the all governance proposals
+//   1: error
+//
+func (s *SmartContract) GetAllGovernanceProposals(ctx contractapi.TransactionContextInterface) (map[string]*GovernanceProposal, error) {
+
+	proposals := make(map[string]*GovernanceProposal)
+	proposalIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(GovernanceProposalObjectType, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer proposalIterator.Close()
+
+	for proposalIterator.HasNext() {
+		proposalJSON, err := proposalIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available proposals: %v", err)
+		}
+		proposal := &GovernanceProposal{}
+		if err = json.Unmarshal(proposalJSON.Value, proposal); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+		}
+		proposals[proposalJSON.Key] = proposal
+	}
+	return proposals, nil
+}
+
+// -- Internal logics
+
+// commitGovernanceProposal applies the voting config of the approved proposal and issues GovernanceCommittedEvent.
+func (s *SmartContract) commitGovernanceProposal(ctx contractapi.TransactionContextInterface, proposal *GovernanceProposal) error {
+	if err := s.markVotingConfigBootstrapped(ctx); err != nil {
+		return err
+	}
+
+	switch proposal.Action {
+	case SetVotingConfigAction:
+		if err := s.putVotingConfig(ctx, proposal.VotingConfig); err != nil {
+			return err
+		}
+	case UnsetVotingConfigAction:
+		if err := s.delVotingConfig(ctx, proposal.VotingConfig.ChannelID); err != nil {
+			return err
+		}
+	}
+
+	proposal.Status = Committed
+	if err := s.putGovernanceProposal(ctx, *proposal); err != nil {
+		return err
+	}
+	return s.setGovernanceEvent(ctx, GovernanceCommittedEvent, *proposal)
+}
+
+func (s *SmartContract) setGovernanceEvent(ctx contractapi.TransactionContextInterface, eventName string, proposal GovernanceProposal) error {
+	return s.setEvent(ctx, eventName, proposal.ID, proposal)
+}
+
+func (s *SmartContract) putGovernanceProposal(ctx contractapi.TransactionContextInterface, proposal GovernanceProposal) error {
+	// Create composite key
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(GovernanceProposalObjectType, []string{proposal.ID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for proposal: %v", err)
+	}
+
+	// struct to JSON
+	proposalJSON, err := json.Marshal(proposal)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the new proposal: %v", err)
+	}
+
+	// Put proposal to StateDB
+	err = ctx.GetStub().PutState(compositeKey, proposalJSON)
+	if err != nil {
+		return fmt.Errorf("error happened persisting the new proposal on the ledger: %v", err)
+	}
+
+	return nil
+}
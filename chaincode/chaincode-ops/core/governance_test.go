@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovernanceProposal(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	ws := newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Case: Request a governance proposal to set the voting config
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	proposal, err := sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:           "governance-1",
+		VotingConfig: VotingConfig{MaxMaliciousOrgs: 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Proposed, proposal.Status)
+	require.Equal(t, SetVotingConfigAction, proposal.Action)
+	require.Equal(t, "Org1MSP", proposal.Creator)
+	require.NotNil(t, ws["history_governance-1_governanceVote_Org1MSP"])
+
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "newGovernanceProposalEvent.governance-1", eventName)
+
+	// The voting config is not changed until the proposal passes the vote
+	config, err := sc.GetVotingConfig(transactionContext)
+	require.NoError(t, err)
+	require.Nil(t, config)
+
+	// Case: Vote for the governance proposal and the proposal is committed
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-1"})
+	require.NoError(t, err)
+
+	proposal, err = sc.GetGovernanceProposal(transactionContext, "governance-1")
+	require.NoError(t, err)
+	require.Equal(t, Committed, proposal.Status)
+
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "governanceCommittedEvent.governance-1", eventName)
+	proposalJSON, err := json.Marshal(proposal)
+	require.NoError(t, err)
+	require.JSONEq(t, string(proposalJSON), string(eventPayload))
+
+	config, err = sc.GetVotingConfig(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, &VotingConfig{ObjectType: VotingConfigObjectType, MaxMaliciousOrgs: 0}, config)
+
+	// Case: Fail to vote for the governance proposal which is already committed
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-1"})
+	require.EqualError(t, err, "the voting is already closed")
+
+	// Case: The voting config does not allow a single org to change the voting config
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	proposal, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:     "governance-2",
+		Action: UnsetVotingConfigAction,
+	})
+	require.NoError(t, err)
+	require.Equal(t, Proposed, proposal.Status)
+
+	// Case: Vote against the governance proposal and the proposal is rejected
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-2", Status: Disagreed})
+	require.NoError(t, err)
+
+	proposal, err = sc.GetGovernanceProposal(transactionContext, "governance-2")
+	require.NoError(t, err)
+	require.Equal(t, Rejected, proposal.Status)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "governanceRejectedEvent.governance-2", eventName)
+
+	config, err = sc.GetVotingConfig(transactionContext)
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	// Case: Unset the voting config through a governance proposal
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:     "governance-3",
+		Action: UnsetVotingConfigAction,
+	})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-3"})
+	require.NoError(t, err)
+
+	config, err = sc.GetVotingConfig(transactionContext)
+	require.NoError(t, err)
+	require.Nil(t, config)
+
+	proposals, err := sc.GetAllGovernanceProposals(transactionContext)
+	require.NoError(t, err)
+	require.Len(t, proposals, 3)
+
+	// Case: Fail to set the voting config directly even after the default voting config is unset
+	require.NotNil(t, ws[VotingConfigBootstrappedKey])
+	err = sc.SetMaxMaliciousOrgsInVotes(transactionContext, 0)
+	require.ErrorIs(t, err, ErrVotingConfigAlreadySet)
+}
+
+func TestWithdrawGovernanceProposal(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err := sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:           "governance-1",
+		VotingConfig: VotingConfig{MaxMaliciousOrgs: 1},
+	})
+	require.NoError(t, err)
+
+	// Case: Failure due to the request of anyone other than the proposer
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.WithdrawGovernanceProposal(transactionContext, "governance-1")
+	require.EqualError(t, err, "only the proposer (Org1MSP) can withdraw the proposal")
+
+	// Case: Withdraw the governance proposal
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.WithdrawGovernanceProposal(transactionContext, "governance-1")
+	require.NoError(t, err)
+
+	proposal, err := sc.GetGovernanceProposal(transactionContext, "governance-1")
+	require.NoError(t, err)
+	require.Equal(t, Withdrawn, proposal.Status)
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "governanceWithdrawnEvent.governance-1", eventName)
+
+	// Case: Failure due to the voting is closed
+	err = sc.WithdrawGovernanceProposal(transactionContext, "governance-1")
+	require.EqualError(t, err, "the voting is already closed")
+
+	// Case: Failure that the proposal is not found
+	err = sc.WithdrawGovernanceProposal(transactionContext, "governance-2")
+	require.EqualError(t, err, "proposal not found")
+}
+
+func TestRequestGovernanceProposalWithInvalidInputParameters(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Case: Fail to request when the proposal ID is empty
+	_, err := sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{})
+	require.EqualError(t, err, "the required parameter proposal 'ID' is empty")
+
+	// Case: Fail to request when the action is invalid
+	_, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{ID: "governance-1", Action: "invalid"})
+	require.EqualError(t, err, "incorrect action type - expecting set or unset")
+
+	// Case: Fail to request when the number of max malicious orgs is invalid
+	_, err = sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{ID: "governance-1", VotingConfig: VotingConfig{MaxMaliciousOrgs: -1}})
+	require.EqualError(t, err, "number of max malicious orgs in votes should be greater than 0")
+}
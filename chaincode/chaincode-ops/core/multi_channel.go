@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// RequestMultiChannelProposal requests a chaincode update proposal which deploys the same chaincode to several application channels.
+// The proposal is stored as a release proposal with the given ID, which has a member proposal for each channel
+// (whose ID is "<ID>@<channelID>"), so that the deployment tasks (acknowledge and commit) are tracked per channel.
+// As with the other release proposals, the proposal is voted for through VoteForReleaseProposal only by the orgs in any of the channels,
+// and it is approved when the votes from the orgs in each channel meet the criteria for the channel.
+//
+// Arguments:
+//   0: input - the request input for the chaincode update proposal with ChannelIDs instead of ChannelID
+//
+// Returns:
+//   0: the created release proposal
+//   1: error
+//
+// Events:
+//   the same as RequestReleaseProposal
+//
+func (s *SmartContract) RequestMultiChannelProposal(ctx contractapi.TransactionContextInterface, input ChaincodeUpdateProposalInput) (*ReleaseProposal, error) {
+
+	if err := s.authorize(ctx, "RequestMultiChannelProposal"); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if input.ChannelID != "" {
+		return nil, fmt.Errorf("the parameter 'ChannelID' is not available for a multi-channel proposal (use 'ChannelIDs' instead)")
+	}
+
+	if len(input.ChannelIDs) < 2 {
+		return nil, fmt.Errorf("the parameter 'ChannelIDs' should have two or more channels")
+	}
+
+	// Build a member for each channel
+	releaseInput := ReleaseProposalInput{ID: input.ID}
+	channelIDs := map[string]bool{}
+	for _, channelID := range input.ChannelIDs {
+		if channelID == "" {
+			return nil, fmt.Errorf("the parameter 'ChannelIDs' should not contain an empty channel ID")
+		}
+		if channelIDs[channelID] {
+			return nil, fmt.Errorf("the channel %s is duplicated in the parameter 'ChannelIDs'", channelID)
+		}
+		channelIDs[channelID] = true
+
+		member := input
+		member.ID = multiChannelMemberID(input.ID, channelID)
+		member.ChannelID = channelID
+		member.ChannelIDs = nil
+		releaseInput.Members = append(releaseInput.Members, member)
+	}
+	return s.requestReleaseProposal(ctx, releaseInput)
+}
+
+// -- Internal logics
+
+// multiChannelMemberID returns the ID of the member proposal for the channel of the multi-channel proposal.
+func multiChannelMemberID(proposalID string, channelID string) string {
+	return fmt.Sprintf("%s@%s", proposalID, channelID)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+var org4MSP = marshalProtoOrPanic(&msp.SerializedIdentity{Mspid: "Org4MSP", IdBytes: []byte("myid")})
+
+// invokeChaincodeWithChannels returns a dummy implementation for chaincode to chaincode with the given orgs for each channel.
+func invokeChaincodeWithChannels(channelOrgs map[string][]string) func(string, [][]byte, string) peer.Response {
+	return func(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
+		funcName := string(arg2[0])
+		switch funcName {
+		case "GetOrganizationsInChannel":
+			orgListJSON, err := json.Marshal(channelOrgs[string(arg2[1])])
+			if err != nil {
+				panic(err)
+			}
+			return peer.Response{Status: shim.OK, Payload: orgListJSON}
+		case "CountOrganizationsInChannel":
+			return peer.Response{Status: shim.OK, Payload: []byte(strconv.Itoa(len(channelOrgs[string(arg2[1])])))}
+		case "GetChannelType":
+			return peer.Response{Status: shim.OK, Payload: []byte("application")}
+		}
+		panic("Unexpected func name")
+	}
+}
+
+func TestMultiChannelProposal(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithChannels(map[string][]string{
+		"channel-a": {"Org1MSP", "Org2MSP"},
+		"channel-b": {"Org2MSP", "Org3MSP"},
+	})
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	requestProposal := func(proposalID string, chaincodeName string) (*ReleaseProposal, error) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChannelID = ""
+		input.ChannelIDs = []string{"channel-b", "channel-a"}
+		input.ChaincodeName = chaincodeName
+		return sc.RequestMultiChannelProposal(transactionContext, input)
+	}
+	vote := func(creator []byte, request TaskStatusUpdateRequest) error {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		return sc.VoteForReleaseProposal(transactionContext, request)
+	}
+	acknowledge := func(creator []byte, proposalID string) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+	}
+	requireStatus := func(proposalID string, status string) {
+		release, err := sc.GetReleaseProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, release.Status)
+	}
+
+	// Case: Request a proposal for multiple channels, which has a member for each channel
+	release, err := requestProposal("deploy-basic", "basic")
+	require.NoError(t, err)
+	require.Equal(t, []string{"channel-a", "channel-b"}, release.ChannelIDs)
+	require.Equal(t, []string{"deploy-basic@channel-b", "deploy-basic@channel-a"}, release.Members)
+	member, err := sc.GetProposal(transactionContext, "deploy-basic@channel-a")
+	require.NoError(t, err)
+	require.Equal(t, "channel-a", member.ChannelID)
+	require.Equal(t, "basic", member.ChaincodeName)
+
+	// Case: The org which is not in any of the channels cannot vote
+	require.ErrorIs(t, vote(org4MSP, TaskStatusUpdateRequest{ProposalID: "deploy-basic"}), ErrNotEligibleVoter)
+
+	// Case: The votes from the orgs in a channel are not counted for the other channels
+	require.NoError(t, vote(org2MSP, TaskStatusUpdateRequest{ProposalID: "deploy-basic"}))
+	requireStatus("deploy-basic", Proposed)
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "newReleaseVoteEvent.deploy-basic", eventName)
+
+	// Case: The proposal is approved when the votes meet the criteria in every channel
+	require.NoError(t, vote(org3MSP, TaskStatusUpdateRequest{ProposalID: "deploy-basic"}))
+	requireStatus("deploy-basic", Approved)
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "releaseApprovedEvent.deploy-basic", eventName)
+	var eventDetail ReleaseDeploymentEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Equal(t, "channel-b", eventDetail.Deployments[0].Proposal.ChannelID)
+	require.Equal(t, []string{"Org2MSP", "Org3MSP"}, eventDetail.Deployments[0].OperationTargets)
+	require.Equal(t, "channel-a", eventDetail.Deployments[1].Proposal.ChannelID)
+	require.Equal(t, []string{"Org1MSP", "Org2MSP"}, eventDetail.Deployments[1].OperationTargets)
+
+	// Case: The deployment tasks are tracked per channel
+	acknowledge(org1MSP, "deploy-basic@channel-a")
+	acknowledge(org2MSP, "deploy-basic@channel-a")
+	requireStatus("deploy-basic", Approved)
+	acknowledge(org2MSP, "deploy-basic@channel-b")
+	acknowledge(org3MSP, "deploy-basic@channel-b")
+	requireStatus("deploy-basic", Acknowledged)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "releaseDeployEvent.deploy-basic", eventName)
+
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "deploy-basic@channel-b", TaskID: Acknowledge})
+	require.NoError(t, err)
+	orgs := []string{}
+	for _, history := range histories {
+		orgs = append(orgs, history.OrgID)
+	}
+	require.ElementsMatch(t, []string{"Org2MSP", "Org3MSP"}, orgs)
+
+	// Case: The proposal is rejected when the criteria become unachievable in any channel
+	_, err = requestProposal("deploy-other", "other")
+	require.NoError(t, err)
+	require.NoError(t, vote(org3MSP, TaskStatusUpdateRequest{ProposalID: "deploy-other", Status: Disagreed}))
+	requireStatus("deploy-other", Rejected)
+
+	// Case: Fail to request the proposal with invalid channels
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	input.ID = "deploy-invalid"
+	input.ChannelIDs = []string{"channel-a", "channel-b"}
+	_, err = sc.RequestMultiChannelProposal(transactionContext, input)
+	require.EqualError(t, err, "the parameter 'ChannelID' is not available for a multi-channel proposal (use 'ChannelIDs' instead)")
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the parameter 'ChannelIDs' is only available for RequestMultiChannelProposal")
+
+	input.ChannelID = ""
+	input.ChannelIDs = []string{"channel-a"}
+	_, err = sc.RequestMultiChannelProposal(transactionContext, input)
+	require.EqualError(t, err, "the parameter 'ChannelIDs' should have two or more channels")
+
+	input.ChannelIDs = []string{"channel-a", "channel-a"}
+	_, err = sc.RequestMultiChannelProposal(transactionContext, input)
+	require.EqualError(t, err, "the channel channel-a is duplicated in the parameter 'ChannelIDs'")
+}
+
+func TestMultiChannelProposalWithAuthorizationRules(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithChannels(map[string][]string{
+		"channel-a": {"Org1MSP", "Org2MSP"},
+		"channel-b": {"Org2MSP", "Org3MSP"},
+	})
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// Prepare: Org1 allows only the operators to request multi-channel proposals, and only the admins to request release proposals
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	transactionContext.GetClientIdentityReturns(newClientIdentity("org1-admin", []string{"admin"}, nil))
+	_, err := sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{
+		{Function: "RequestMultiChannelProposal", Attributes: []string{"opssc.role=operator"}},
+		{Function: "RequestReleaseProposal", Roles: []string{"admin"}},
+	})
+	require.NoError(t, err)
+
+	// Case: The operator can request a multi-channel proposal without the permission to request release proposals
+	transactionContext.GetClientIdentityReturns(newClientIdentity("org1-operator", []string{"client"}, map[string]string{"opssc.role": "operator"}))
+	_, input := baseProposalAndInput("")
+	input.ChannelID = ""
+	input.ChannelIDs = []string{"channel-a", "channel-b"}
+	release, err := sc.RequestMultiChannelProposal(transactionContext, input)
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1@channel-a", "request-1@channel-b"}, release.Members)
+
+	_, err = sc.RequestReleaseProposal(transactionContext, ReleaseProposalInput{ID: "release-1"})
+	require.ErrorIs(t, err, ErrUnauthorized)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// PackageMismatchEventDetail represents details of PackageMismatchEvent.
+type PackageMismatchEventDetail struct {
+	Proposal      ChaincodeUpdateProposal `json:"proposal"`
+	PackageIDs    map[string]string       `json:"packageIDs"`    // MSP ID -> the package ID computed by the org
+	DivergentOrgs []string                `json:"divergentOrgs"` // the orgs whose packages differ from the package computed by the most orgs
+}
+
+// detectPackageMismatch compares the package IDs computed by the orgs which acknowledged the deployment successfully.
+// If the packages differ, this issues PackageMismatchEvent and returns true.
+// The orgs which do not report their package IDs are not compared.
+func (s *SmartContract) detectPackageMismatch(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, currentHistory History) (bool, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(HistoryObjectType, []string{currentHistory.ProposalID, Acknowledge})
+	if err != nil {
+		return false, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	packageIDs := map[string]string{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return false, fmt.Errorf("error happened iterating over available histories: %v", err)
+		}
+		var history History
+		if err = json.Unmarshal(result.Value, &history); err != nil {
+			return false, fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+		}
+		if packageID := packageIDOf(history); history.Status == Success && packageID != "" {
+			packageIDs[history.OrgID] = packageID
+		}
+	}
+	delete(packageIDs, currentHistory.OrgID)
+	if packageID := packageIDOf(currentHistory); packageID != "" {
+		packageIDs[currentHistory.OrgID] = packageID
+	}
+
+	divergentOrgs := findDivergentOrgs(packageIDs, proposal.Creator)
+	if len(divergentOrgs) == 0 {
+		return false, nil
+	}
+
+	eventDetail := PackageMismatchEventDetail{
+		Proposal:      proposal,
+		PackageIDs:    packageIDs,
+		DivergentOrgs: divergentOrgs,
+	}
+	if err := s.setEvent(ctx, PackageMismatchEvent, proposal.ID, eventDetail); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// packageIDOf returns the package ID in the history.
+// For the agents which report the package ID only in the data (e.g., {"packageID": "basic_1.0:<hash>"}), this reads it from the data.
+func packageIDOf(history History) string {
+	if history.PackageID != "" {
+		return history.PackageID
+	}
+	var data struct {
+		PackageID string `json:"packageID"`
+	}
+	if err := json.Unmarshal([]byte(history.Data), &data); err != nil {
+		return ""
+	}
+	return data.PackageID
+}
+
+// packageHash returns the SHA-256 hash part of the package ID (<label>:<hash>) to compare the packages regardless of the labels.
+func packageHash(packageID string) string {
+	return packageID[strings.LastIndex(packageID, ":")+1:]
+}
+
+// findDivergentOrgs returns the orgs (in sorted order) whose packages differ from the package computed by the most orgs.
+// If several packages are computed by the most orgs, the package computed by the proposer (or else the smallest hash) is preferred.
+func findDivergentOrgs(packageIDs map[string]string, proposer string) []string {
+	counts := map[string]int{}
+	for _, packageID := range packageIDs {
+		counts[packageHash(packageID)]++
+	}
+	if len(counts) <= 1 {
+		return nil
+	}
+
+	proposerHash := ""
+	if packageID, ok := packageIDs[proposer]; ok {
+		proposerHash = packageHash(packageID)
+	}
+	isPreferred := func(hash string, than string) bool {
+		if counts[hash] != counts[than] {
+			return counts[hash] > counts[than]
+		}
+		if hash == proposerHash || than == proposerHash {
+			return hash == proposerHash
+		}
+		return hash < than
+	}
+	reference := ""
+	for hash := range counts {
+		if reference == "" || isPreferred(hash, reference) {
+			reference = hash
+		}
+	}
+
+	divergentOrgs := []string{}
+	for mspID, packageID := range packageIDs {
+		if packageHash(packageID) != reference {
+			divergentOrgs = append(divergentOrgs, mspID)
+		}
+	}
+	sort.Strings(divergentOrgs)
+	return divergentOrgs
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgeWithPackageIDs(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	prepareApprovedProposal := func(proposalID string) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = proposalID
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+	}
+	acknowledge := func(creator []byte, request TaskStatusUpdateRequest) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		require.NoError(t, sc.Acknowledge(transactionContext, request))
+	}
+	requireStatus := func(proposalID string, status string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, proposal.Status)
+	}
+	requireMismatchEvent := func(proposalID string, packageIDs map[string]string, divergentOrgs []string) {
+		eventName, eventPayload := lastEvent(chaincodeStub)
+		require.Equal(t, "packageMismatchEvent."+proposalID, eventName)
+		var eventDetail PackageMismatchEventDetail
+		require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+		require.Equal(t, proposalID, eventDetail.Proposal.ID)
+		require.Equal(t, packageIDs, eventDetail.PackageIDs)
+		require.Equal(t, divergentOrgs, eventDetail.DivergentOrgs)
+	}
+
+	// Case: The proposal is acknowledged when all orgs compute the same package
+	prepareApprovedProposal("request-1")
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "request-1", PackageID: "request-1_1.0:aaa"})
+	acknowledge(org2MSP, TaskStatusUpdateRequest{ProposalID: "request-1", PackageID: "request-1_1.0:aaa"})
+	acknowledge(org3MSP, TaskStatusUpdateRequest{ProposalID: "request-1", Data: `{"packageID": "request-1_1.0:aaa"}`})
+	requireStatus("request-1", Acknowledged)
+
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "request-1", TaskID: Acknowledge})
+	require.NoError(t, err)
+	packageIDs := map[string]string{}
+	for _, history := range histories {
+		packageIDs[history.OrgID] = history.PackageID
+	}
+	require.Equal(t, map[string]string{"Org1MSP": "request-1_1.0:aaa", "Org2MSP": "request-1_1.0:aaa", "Org3MSP": ""}, packageIDs)
+
+	// Case: The proposal is not acknowledged and the divergent org is notified when the packages differ
+	prepareApprovedProposal("request-2")
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "request-2", PackageID: "request-2_1.0:aaa"})
+	acknowledge(org2MSP, TaskStatusUpdateRequest{ProposalID: "request-2", Data: `{"packageID": "request-2_1.0:bbb"}`})
+	requireStatus("request-2", Approved)
+	requireMismatchEvent("request-2",
+		map[string]string{"Org1MSP": "request-2_1.0:aaa", "Org2MSP": "request-2_1.0:bbb"},
+		[]string{"Org2MSP"})
+
+	acknowledge(org3MSP, TaskStatusUpdateRequest{ProposalID: "request-2", PackageID: "request-2_1.0:bbb"})
+	requireStatus("request-2", Approved)
+	requireMismatchEvent("request-2",
+		map[string]string{"Org1MSP": "request-2_1.0:aaa", "Org2MSP": "request-2_1.0:bbb", "Org3MSP": "request-2_1.0:bbb"},
+		[]string{"Org1MSP"})
+
+	// Case: The proposal is acknowledged after the divergent org acknowledges again with the same package
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "request-2", PackageID: "request-2_1.0:bbb"})
+	requireStatus("request-2", Acknowledged)
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "deployEvent.request-2", eventName)
+
+	// Case: The org which does not report the package ID is not compared
+	prepareApprovedProposal("request-3")
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "request-3", PackageID: "request-3_1.0:aaa"})
+	acknowledge(org2MSP, TaskStatusUpdateRequest{ProposalID: "request-3", Data: "installed"})
+	acknowledge(org3MSP, TaskStatusUpdateRequest{ProposalID: "request-3", PackageID: "other_label:aaa"})
+	requireStatus("request-3", Acknowledged)
+}
+
+func TestFindDivergentOrgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		packageIDs    map[string]string
+		proposer      string
+		divergentOrgs []string
+	}{
+		{
+			name:       "no package",
+			packageIDs: map[string]string{},
+		},
+		{
+			name:       "same packages with different labels",
+			packageIDs: map[string]string{"Org1MSP": "basic_1.0:aaa", "Org2MSP": "basic:aaa", "Org3MSP": "aaa"},
+		},
+		{
+			name:          "the package computed by the most orgs is preferred",
+			packageIDs:    map[string]string{"Org1MSP": "basic_1.0:aaa", "Org2MSP": "basic_1.0:bbb", "Org3MSP": "basic_1.0:bbb"},
+			proposer:      "Org1MSP",
+			divergentOrgs: []string{"Org1MSP"},
+		},
+		{
+			name:          "the package computed by the proposer is preferred in a tie",
+			packageIDs:    map[string]string{"Org1MSP": "basic_1.0:bbb", "Org2MSP": "basic_1.0:aaa"},
+			proposer:      "Org1MSP",
+			divergentOrgs: []string{"Org2MSP"},
+		},
+		{
+			name:          "the smallest hash is preferred in a tie without the package of the proposer",
+			packageIDs:    map[string]string{"Org2MSP": "basic_1.0:bbb", "Org3MSP": "basic_1.0:aaa", "Org4MSP": "basic_1.0:ccc"},
+			proposer:      "Org1MSP",
+			divergentOrgs: []string{"Org2MSP", "Org4MSP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.divergentOrgs, findDivergentOrgs(tt.packageIDs, tt.proposer))
+		})
+	}
+}
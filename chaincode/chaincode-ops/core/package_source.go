@@ -0,0 +1,124 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// Chaincode package source types
+const (
+	GitSource      = "git"      // the source code in a git repository
+	OCIImageSource = "ociImage" // the OCI image of the chaincode as a service (CCaaS) pinned by its digest
+	ArchiveSource  = "archive"  // the prebuilt chaincode package (.tar.gz) pinned by its SHA-256 hash
+)
+
+var (
+	imageNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]+([.-][A-Za-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+	imageDigestPattern = regexp.MustCompile(`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`)
+	archiveHashPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+// validateChaincodePackage validates the chaincode package according to its source type.
+func validateChaincodePackage(chaincodePackage ChaincodePackage) error {
+	sourceType := chaincodePackage.SourceType
+	if sourceType == "" {
+		sourceType = GitSource
+	}
+	if sourceType != GitSource && sourceType != OCIImageSource && sourceType != ArchiveSource {
+		return fmt.Errorf("the parameter 'ChaincodePackage.SourceType' should be %s, %s or %s", GitSource, OCIImageSource, ArchiveSource)
+	}
+
+	// Only the fields for the source type can be set
+	fields := []struct {
+		sourceType string
+		name       string
+		value      string
+	}{
+		{GitSource, "Repository", chaincodePackage.Repository},
+		{GitSource, "CommitID", chaincodePackage.CommitID},
+		{GitSource, "PathToSourceFiles", chaincodePackage.PathToSourceFiles},
+		{OCIImageSource, "ImageName", chaincodePackage.ImageName},
+		{OCIImageSource, "ImageDigest", chaincodePackage.ImageDigest},
+		{ArchiveSource, "ArchiveURL", chaincodePackage.ArchiveURL},
+		{ArchiveSource, "ArchiveHash", chaincodePackage.ArchiveHash},
+	}
+	for _, field := range fields {
+		if field.sourceType != sourceType && field.value != "" {
+			return fmt.Errorf("the parameter 'ChaincodePackage.%s' is not available for the source type %s", field.name, sourceType)
+		}
+	}
+
+	if chaincodePackage.Type == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.Type' is empty")
+	}
+
+	switch sourceType {
+	case GitSource:
+		return validateGitSource(chaincodePackage)
+	case OCIImageSource:
+		return validateOCIImageSource(chaincodePackage)
+	default:
+		return validateArchiveSource(chaincodePackage)
+	}
+}
+
+// validateGitSource validates the chaincode package whose source code is in a git repository.
+func validateGitSource(chaincodePackage ChaincodePackage) error {
+	if chaincodePackage.Repository == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.Repository' is empty")
+	}
+	url, err := url.Parse(chaincodePackage.Repository)
+	if err != nil || url.Scheme != "" {
+		return fmt.Errorf("the parameter 'ChaincodePackage.Repository' should be repository path (e.g., github.com/project_name/repository_name)")
+	}
+	if chaincodePackage.CommitID == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.CommitID' is empty")
+	}
+	return nil
+}
+
+// validateOCIImageSource validates the chaincode package whose source is an OCI image pinned by its digest.
+func validateOCIImageSource(chaincodePackage ChaincodePackage) error {
+	if chaincodePackage.ImageName == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.ImageName' is empty")
+	}
+	if !imageNamePattern.MatchString(chaincodePackage.ImageName) {
+		return fmt.Errorf("the parameter 'ChaincodePackage.ImageName' should be image name without tag and digest (e.g., ghcr.io/project_name/image_name)")
+	}
+	if chaincodePackage.ImageDigest == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.ImageDigest' is empty")
+	}
+	if !imageDigestPattern.MatchString(chaincodePackage.ImageDigest) {
+		return fmt.Errorf("the parameter 'ChaincodePackage.ImageDigest' should be image digest (e.g., sha256:<64 hex characters>)")
+	}
+	// The image is launched as a chaincode server, so it is only available for the chaincode as a service
+	if chaincodePackage.Type != "ccaas" && chaincodePackage.Type != "k8s" {
+		return fmt.Errorf("the parameter 'ChaincodePackage.Type' should be ccaas or k8s for the source type %s", OCIImageSource)
+	}
+	return nil
+}
+
+// validateArchiveSource validates the chaincode package which is a prebuilt package archive pinned by its hash.
+func validateArchiveSource(chaincodePackage ChaincodePackage) error {
+	if chaincodePackage.ArchiveURL == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.ArchiveURL' is empty")
+	}
+	url, err := url.Parse(chaincodePackage.ArchiveURL)
+	if err != nil || (url.Scheme != "https" && url.Scheme != "http") || url.Host == "" {
+		return fmt.Errorf("the parameter 'ChaincodePackage.ArchiveURL' should be HTTP(S) URL (e.g., https://example.com/basic.tar.gz)")
+	}
+	if chaincodePackage.ArchiveHash == "" {
+		return fmt.Errorf("the required parameter 'ChaincodePackage.ArchiveHash' is empty")
+	}
+	if !archiveHashPattern.MatchString(chaincodePackage.ArchiveHash) {
+		return fmt.Errorf("the parameter 'ChaincodePackage.ArchiveHash' should be SHA-256 hash (64 hex characters)")
+	}
+	return nil
+}
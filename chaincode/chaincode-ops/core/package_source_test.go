@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateChaincodePackage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	hash := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name             string
+		chaincodePackage ChaincodePackage
+		expectedErr      string
+	}{
+		{
+			name:             "git source without the source type",
+			chaincodePackage: ChaincodePackage{Repository: "github.com/hyperledger/fabric-samples", CommitID: "main", PathToSourceFiles: "asset-transfer-basic/chaincode-go", Type: "golang"},
+		},
+		{
+			name:             "git source",
+			chaincodePackage: ChaincodePackage{SourceType: GitSource, Repository: "github.com/hyperledger/fabric-samples", CommitID: "main", Type: "golang"},
+		},
+		{
+			name:             "git source without repository",
+			chaincodePackage: ChaincodePackage{CommitID: "main", Type: "golang"},
+			expectedErr:      "the required parameter 'ChaincodePackage.Repository' is empty",
+		},
+		{
+			name:             "git source with the field for another source type",
+			chaincodePackage: ChaincodePackage{Repository: "github.com/hyperledger/fabric-samples", CommitID: "main", ImageDigest: digest, Type: "golang"},
+			expectedErr:      "the parameter 'ChaincodePackage.ImageDigest' is not available for the source type git",
+		},
+		{
+			name:             "OCI image source",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", ImageDigest: digest, Type: "ccaas"},
+		},
+		{
+			name:             "OCI image source in the registry with port",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "localhost:5000/basic_cc", ImageDigest: "sha512:" + strings.Repeat("b", 128), Type: "ccaas"},
+		},
+		{
+			name:             "OCI image source for the k8s builder",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", ImageDigest: digest, Type: "k8s"},
+		},
+		{
+			name:             "OCI image source for the chaincode built by the peer",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", ImageDigest: digest, Type: "golang"},
+			expectedErr:      "the parameter 'ChaincodePackage.Type' should be ccaas or k8s for the source type ociImage",
+		},
+		{
+			name:             "OCI image source without image name",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageDigest: digest, Type: "ccaas"},
+			expectedErr:      "the required parameter 'ChaincodePackage.ImageName' is empty",
+		},
+		{
+			name:             "OCI image source with tag",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas:latest", ImageDigest: digest, Type: "ccaas"},
+			expectedErr:      "the parameter 'ChaincodePackage.ImageName' should be image name without tag and digest (e.g., ghcr.io/project_name/image_name)",
+		},
+		{
+			name:             "OCI image source without image digest",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", Type: "ccaas"},
+			expectedErr:      "the required parameter 'ChaincodePackage.ImageDigest' is empty",
+		},
+		{
+			name:             "OCI image source with tag instead of digest",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", ImageDigest: "latest", Type: "ccaas"},
+			expectedErr:      "the parameter 'ChaincodePackage.ImageDigest' should be image digest (e.g., sha256:<64 hex characters>)",
+		},
+		{
+			name:             "OCI image source with commit ID",
+			chaincodePackage: ChaincodePackage{SourceType: OCIImageSource, ImageName: "ghcr.io/hyperledger/basic-ccaas", ImageDigest: digest, CommitID: "main", Type: "ccaas"},
+			expectedErr:      "the parameter 'ChaincodePackage.CommitID' is not available for the source type ociImage",
+		},
+		{
+			name:             "archive source",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveURL: "https://example.com/packages/basic.tar.gz", ArchiveHash: hash, Type: "golang"},
+		},
+		{
+			name:             "archive source without URL",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveHash: hash, Type: "golang"},
+			expectedErr:      "the required parameter 'ChaincodePackage.ArchiveURL' is empty",
+		},
+		{
+			name:             "archive source with non HTTP URL",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveURL: "file:///tmp/basic.tar.gz", ArchiveHash: hash, Type: "golang"},
+			expectedErr:      "the parameter 'ChaincodePackage.ArchiveURL' should be HTTP(S) URL (e.g., https://example.com/basic.tar.gz)",
+		},
+		{
+			name:             "archive source without hash",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveURL: "https://example.com/packages/basic.tar.gz", Type: "golang"},
+			expectedErr:      "the required parameter 'ChaincodePackage.ArchiveHash' is empty",
+		},
+		{
+			name:             "archive source with invalid hash",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveURL: "https://example.com/packages/basic.tar.gz", ArchiveHash: "sha256:" + hash, Type: "golang"},
+			expectedErr:      "the parameter 'ChaincodePackage.ArchiveHash' should be SHA-256 hash (64 hex characters)",
+		},
+		{
+			name:             "archive source without type",
+			chaincodePackage: ChaincodePackage{SourceType: ArchiveSource, ArchiveURL: "https://example.com/packages/basic.tar.gz", ArchiveHash: hash},
+			expectedErr:      "the required parameter 'ChaincodePackage.Type' is empty",
+		},
+		{
+			name:             "unknown source type",
+			chaincodePackage: ChaincodePackage{SourceType: "svn", Repository: "svn.example.com/basic", CommitID: "1", Type: "golang"},
+			expectedErr:      "the parameter 'ChaincodePackage.SourceType' should be git, ociImage or archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChaincodePackage(tt.chaincodePackage)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ProposalQueryParams represents query parameters for getting a page of proposals from the ledger.
+type ProposalQueryParams struct {
+	PageSize      int    `json:"pageSize"`                                // DefaultPageSize is used if this is 0
+	Bookmark      string `json:"bookmark,omitempty" metadata:",optional"` // the bookmark returned with the previous page
+	Status        string `json:"status,omitempty" metadata:",optional"`
+	ChannelID     string `json:"channelID,omitempty" metadata:",optional"`
+	ChaincodeName string `json:"chaincodeName,omitempty" metadata:",optional"`
+	Creator       string `json:"creator,omitempty" metadata:",optional"`
+	From          string `json:"from,omitempty" metadata:",optional"` // RFC3339 (inclusive)
+	To            string `json:"to,omitempty" metadata:",optional"`   // RFC3339 (exclusive)
+}
+
+// ProposalQueryResult represents a page of proposals.
+type ProposalQueryResult struct {
+	Proposals []*ChaincodeUpdateProposal `json:"proposals"`
+	Bookmark  string                     `json:"bookmark"` // empty if there is no next page
+}
+
+// HistoryQueryResult represents a page of histories.
+type HistoryQueryResult struct {
+	Histories []*History `json:"histories"`
+	Bookmark  string     `json:"bookmark"` // empty if there is no next page
+}
+
+// Page sizes for queries
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	// MaxScannedRecords is the max number of the records scanned in a call of QueryProposals
+	MaxScannedRecords = 1000
+)
+
+// Fields which can be used in selectors for rich queries (true if the field is indexed in META-INF/statedb/couchdb/indexes)
+var (
+	proposalSelectorFields = map[string]bool{"status": true, "channelID": true, "chaincodeName": true, "time": true, "creator": false}
+	historySelectorFields  = map[string]bool{"proposalID": true, "orgID": true, "taskID": true, "status": false, "time": false}
+)
+
+// Operators which can be used in selectors for rich queries
+var selectorOperators = map[string]bool{"$eq": true, "$in": true, "$gt": true, "$gte": true, "$lt": true, "$lte": true}
+
+// QueryProposals returns a page of the chaincode update proposals which match the given query parameters.
+// The proposals are ordered by proposal ID.
+// A call scans at most MaxScannedRecords proposals, so the page may have fewer proposals than the page size
+// even if the bookmark for the next page is returned.
+//
+// Arguments:
+//   0: params - the proposal query parameters
+//
+// Returns:
+//   0: the page of the proposals and the bookmark for the next page
+//   1: error
+//
+func (s *SmartContract) QueryProposals(ctx contractapi.TransactionContextInterface, params ProposalQueryParams) (*ProposalQueryResult, error) {
+
+	// Validate input
+	var err error
+	if params.PageSize, err = validatePageSize(params.PageSize); err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	if params.From != "" {
+		if from, err = time.Parse(time.RFC3339, params.From); err != nil {
+			return nil, fmt.Errorf("the parameter 'From' should be RFC3339 format: %v", err)
+		}
+	}
+	if params.To != "" {
+		if to, err = time.Parse(time.RFC3339, params.To); err != nil {
+			return nil, fmt.Errorf("the parameter 'To' should be RFC3339 format: %v", err)
+		}
+	}
+
+	matches := func(proposal *ChaincodeUpdateProposal) bool {
+		if params.Status != "" && proposal.Status != params.Status {
+			return false
+		}
+		if params.ChannelID != "" && proposal.ChannelID != params.ChannelID {
+			return false
+		}
+		if params.ChaincodeName != "" && proposal.ChaincodeName != params.ChaincodeName {
+			return false
+		}
+		if params.Creator != "" && proposal.Creator != params.Creator {
+			return false
+		}
+		if params.From != "" || params.To != "" {
+			proposalTime, err := time.Parse(time.RFC3339, proposal.Time)
+			if err != nil {
+				return false
+			}
+			if params.From != "" && proposalTime.Before(from) {
+				return false
+			}
+			if params.To != "" && !proposalTime.Before(to) {
+				return false
+			}
+		}
+		return true
+	}
+
+	result := &ProposalQueryResult{Proposals: []*ChaincodeUpdateProposal{}}
+	bookmark := params.Bookmark
+	scanned := 0
+	for {
+		iterator, metadata, err := ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(ProposalObjectType, []string{}, int32(params.PageSize), bookmark)
+		if err != nil {
+			return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+		}
+
+		for iterator.HasNext() {
+			proposalJSON, err := iterator.Next()
+			if err != nil {
+				iterator.Close()
+				return nil, fmt.Errorf("error happened iterating over available proposals: %v", err)
+			}
+			// If the page is full or the scan limit is reached, the key of the next proposal becomes the bookmark for the next page
+			if len(result.Proposals) == params.PageSize || scanned == MaxScannedRecords {
+				iterator.Close()
+				result.Bookmark = proposalJSON.Key
+				return result, nil
+			}
+			scanned++
+			proposal := &ChaincodeUpdateProposal{}
+			if err = json.Unmarshal(proposalJSON.Value, proposal); err != nil {
+				iterator.Close()
+				return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+			}
+			setChaincodeDefinitionDefaults(&proposal.ChaincodeDefinition)
+			if matches(proposal) {
+				result.Proposals = append(result.Proposals, proposal)
+			}
+		}
+		iterator.Close()
+
+		// Fetch the next records until the page is full or all records are read
+		if metadata == nil || metadata.Bookmark == "" || metadata.FetchedRecordsCount < int32(params.PageSize) {
+			return result, nil
+		}
+		bookmark = metadata.Bookmark
+	}
+}
+
+// QueryProposalsWithSelector returns a page of the chaincode update proposals which match the given selector.
+// This uses a rich query, so it is available only if CouchDB is used as the state database.
+//
+// The selector is a constrained CouchDB selector in JSON format:
+// - the fields should be status, channelID, chaincodeName, creator or time,
+//   and at least one of status, channelID, chaincodeName and time (the indexed fields) should be included
+// - the condition for each field should be a value or an object with the operators $eq, $in, $gt, $gte, $lt and $lte
+// (e.g., {"chaincodeName": "basic", "status": "rejected", "time": {"$gte": "2026-01-01T00:00:00Z"}})
+// Note that time is recorded in UTC and compared as a string, so the time condition should be written in UTC (e.g., 2026-01-01T00:00:00Z).
+//
+// Arguments:
+//   0: selector - the selector in JSON format
+//   1: pageSize - the page size (DefaultPageSize is used if this is 0)
+//   2: bookmark - the bookmark returned with the previous page
+//
+// Returns:
+//   0: the page of the proposals and the bookmark for the next page
+//   1: error
+//
+func (s *SmartContract) QueryProposalsWithSelector(ctx contractapi.TransactionContextInterface, selector string, pageSize int, bookmark string) (*ProposalQueryResult, error) {
+
+	query, err := buildRichQuery(ProposalObjectType, selector, proposalSelectorFields)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize, err = validatePageSize(pageSize); err != nil {
+		return nil, err
+	}
+
+	iterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(query, int32(pageSize), bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("error happened executing the rich query: %v", err)
+	}
+	defer iterator.Close()
+
+	result := &ProposalQueryResult{Proposals: []*ChaincodeUpdateProposal{}}
+	for iterator.HasNext() {
+		proposalJSON, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available proposals: %v", err)
+		}
+		proposal := &ChaincodeUpdateProposal{}
+		if err = json.Unmarshal(proposalJSON.Value, proposal); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+		}
+		setChaincodeDefinitionDefaults(&proposal.ChaincodeDefinition)
+		result.Proposals = append(result.Proposals, proposal)
+	}
+	result.Bookmark = nextBookmark(metadata, pageSize)
+	return result, nil
+}
+
+// QueryHistoriesWithSelector returns a page of the histories which match the given selector.
+// This uses a rich query, so it is available only if CouchDB is used as the state database.
+//
+// The selector is a constrained CouchDB selector in JSON format:
+// - the fields should be proposalID, orgID, taskID, status or time,
+//   and at least one of proposalID, orgID and taskID (the indexed fields) should be included
+// - the condition for each field should be a value or an object with the operators $eq, $in, $gt, $gte, $lt and $lte
+// (e.g., {"orgID": "Org1MSP", "taskID": "vote"})
+//
+// Arguments:
+//   0: selector - the selector in JSON format
+//   1: pageSize - the page size (DefaultPageSize is used if this is 0)
+//   2: bookmark - the bookmark returned with the previous page
+//
+// Returns:
+//   0: the page of the histories and the bookmark for the next page
+//   1: error
+//
+func (s *SmartContract) QueryHistoriesWithSelector(ctx contractapi.TransactionContextInterface, selector string, pageSize int, bookmark string) (*HistoryQueryResult, error) {
+
+	query, err := buildRichQuery(HistoryObjectType, selector, historySelectorFields)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize, err = validatePageSize(pageSize); err != nil {
+		return nil, err
+	}
+
+	iterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(query, int32(pageSize), bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("error happened executing the rich query: %v", err)
+	}
+	defer iterator.Close()
+
+	result := &HistoryQueryResult{Histories: []*History{}}
+	for iterator.HasNext() {
+		historyJSON, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available histories: %v", err)
+		}
+		history := &History{}
+		if err = json.Unmarshal(historyJSON.Value, history); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+		}
+		result.Histories = append(result.Histories, history)
+	}
+	result.Bookmark = nextBookmark(metadata, pageSize)
+	return result, nil
+}
+
+// -- Internal logics
+
+// getProposalsByStatus returns the proposals with the given status in the order of their IDs.
+// This uses the status index by a rich query if CouchDB is used as the state database,
+// and otherwise scans the all proposals by the partial composite key.
+// Each proposal found by the rich query is read again by its key, since the results of a rich query are not validated at commit time.
+func (s *SmartContract) getProposalsByStatus(ctx contractapi.TransactionContextInterface, status string) ([]*ChaincodeUpdateProposal, error) {
+	query, err := json.Marshal(map[string]interface{}{
+		"selector":  map[string]string{"docType": ProposalObjectType, "status": status},
+		"use_index": []string{"_design/indexProposalStatusDoc", "indexProposalStatus"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error happened marshalling the rich query: %v", err)
+	}
+
+	iterator, err := ctx.GetStub().GetQueryResult(string(query))
+	if err != nil {
+		// Rich queries are not supported by LevelDB
+		allProposals, err := s.GetAllProposals(ctx)
+		if err != nil {
+			return nil, err
+		}
+		proposals := []*ChaincodeUpdateProposal{}
+		for _, proposal := range allProposals {
+			if proposal.Status == status {
+				proposals = append(proposals, proposal)
+			}
+		}
+		sortProposalsByID(proposals)
+		return proposals, nil
+	}
+	defer iterator.Close()
+
+	proposals := []*ChaincodeUpdateProposal{}
+	for iterator.HasNext() {
+		proposalJSON, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available proposals: %v", err)
+		}
+		found := &ChaincodeUpdateProposal{}
+		if err = json.Unmarshal(proposalJSON.Value, found); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+		}
+		proposal, err := s.GetProposal(ctx, found.ID)
+		if err != nil {
+			return nil, err
+		}
+		if proposal.Status == status {
+			proposals = append(proposals, proposal)
+		}
+	}
+	sortProposalsByID(proposals)
+	return proposals, nil
+}
+
+func sortProposalsByID(proposals []*ChaincodeUpdateProposal) {
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].ID < proposals[j].ID
+	})
+}
+
+func validatePageSize(pageSize int) (int, error) {
+	if pageSize == 0 {
+		return DefaultPageSize, nil
+	}
+	if pageSize < 0 || pageSize > MaxPageSize {
+		return 0, fmt.Errorf("the parameter 'PageSize' should be between 1 and %d", MaxPageSize)
+	}
+	return pageSize, nil
+}
+
+func nextBookmark(metadata *peer.QueryResponseMetadata, pageSize int) string {
+	if metadata == nil || metadata.FetchedRecordsCount < int32(pageSize) {
+		return ""
+	}
+	return metadata.Bookmark
+}
+
+// buildRichQuery validates the given selector with the allowed fields and returns the rich query for the given object type.
+func buildRichQuery(objectType string, selector string, fields map[string]bool) (string, error) {
+	conditions := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(selector), &conditions); err != nil {
+		return "", fmt.Errorf("the parameter 'selector' should be a JSON object: %v", err)
+	}
+
+	indexed := false
+	for field, condition := range conditions {
+		isIndexed, ok := fields[field]
+		if !ok {
+			return "", fmt.Errorf("the field '%s' is not allowed in the selector", field)
+		}
+		if err := validateSelectorCondition(field, condition); err != nil {
+			return "", err
+		}
+		indexed = indexed || isIndexed
+	}
+	if !indexed {
+		indexedFields := []string{}
+		for field, isIndexed := range fields {
+			if isIndexed {
+				indexedFields = append(indexedFields, field)
+			}
+		}
+		sort.Strings(indexedFields)
+		return "", fmt.Errorf("the selector should contain at least one of the fields: %s", strings.Join(indexedFields, ", "))
+	}
+
+	conditions["docType"] = objectType
+	query, err := json.Marshal(map[string]interface{}{"selector": conditions})
+	if err != nil {
+		return "", fmt.Errorf("error happened marshalling the rich query: %v", err)
+	}
+	return string(query), nil
+}
+
+func validateSelectorCondition(field string, condition interface{}) error {
+	if isSelectorValue(condition) {
+		return nil
+	}
+	operators, ok := condition.(map[string]interface{})
+	if !ok || len(operators) == 0 {
+		return fmt.Errorf("the condition for the field '%s' is invalid", field)
+	}
+	for operator, operand := range operators {
+		if !selectorOperators[operator] {
+			return fmt.Errorf("the operator '%s' is not allowed in the selector", operator)
+		}
+		if operator != "$in" {
+			if !isSelectorValue(operand) {
+				return fmt.Errorf("the condition for the field '%s' is invalid", field)
+			}
+			continue
+		}
+		values, ok := operand.([]interface{})
+		if !ok {
+			return fmt.Errorf("the condition for the field '%s' is invalid", field)
+		}
+		for _, value := range values {
+			if !isSelectorValue(value) {
+				return fmt.Errorf("the condition for the field '%s' is invalid", field)
+			}
+		}
+	}
+	return nil
+}
+
+func isSelectorValue(value interface{}) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
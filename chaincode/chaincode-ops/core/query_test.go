@@ -0,0 +1,258 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestQueryProposals(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Prepare proposals: request-1..5 by Org1MSP for basic-1..5 and request-6..7 by Org2MSP for other (one per hour)
+	// (request-6 is withdrawn before request-7 because only one proposal can be open for a chaincode)
+	baseTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 7; i++ {
+		_, input := baseProposalAndInput("")
+		input.ID = fmt.Sprintf("request-%d", i)
+		input.ChaincodeName = fmt.Sprintf("basic-%d", i)
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		if i > 5 {
+			input.ChaincodeName = "other"
+			chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		}
+		if i == 7 {
+			require.NoError(t, sc.WithdrawProposal(transactionContext, "request-6"))
+		}
+		chaincodeStub.GetTxTimestampReturns(timestamppb.New(baseTime.Add(time.Duration(i)*time.Hour)), nil)
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+	}
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err := sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-2", Status: Agreed})
+	require.NoError(t, err)
+
+	proposalIDs := func(result *ProposalQueryResult) []string {
+		ids := []string{}
+		for _, proposal := range result.Proposals {
+			ids = append(ids, proposal.ID)
+		}
+		return ids
+	}
+
+	// Case: Get all proposals with the default page size
+	result, err := sc.QueryProposals(transactionContext, ProposalQueryParams{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1", "request-2", "request-3", "request-4", "request-5", "request-6", "request-7"}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals page by page
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 3})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1", "request-2", "request-3"}, proposalIDs(result))
+	require.Equal(t, "proposal_request-4", result.Bookmark)
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 3, Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-4", "request-5", "request-6"}, proposalIDs(result))
+	require.Equal(t, "proposal_request-7", result.Bookmark)
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 3, Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-7"}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals filtered by the chaincode name and the creator across multiple ledger pages
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 2, ChaincodeName: "other", Creator: "Org2MSP"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-6", "request-7"}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals filtered by the status and the channel ID
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{Status: Approved, ChannelID: "mychannel"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-2"}, proposalIDs(result))
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{ChannelID: "otherchannel"})
+	require.NoError(t, err)
+	require.Empty(t, result.Proposals)
+
+	// Case: Get proposals filtered by the time range
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{From: "2026-01-01T03:00:00Z", To: "2026-01-01T05:00:00Z"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-3", "request-4"}, proposalIDs(result))
+
+	// Case: Fail to get proposals with invalid parameters
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: MaxPageSize + 1})
+	require.EqualError(t, err, "the parameter 'PageSize' should be between 1 and 100")
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{From: "yesterday"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the parameter 'From' should be RFC3339 format")
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{To: "tomorrow"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the parameter 'To' should be RFC3339 format")
+}
+
+func TestQueryProposalsWithScanLimit(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	newWorldState(chaincodeStub)
+
+	sc := SmartContract{}
+
+	// Prepare proposals: only the last one after the scan limit is in otherchannel
+	for i := 1; i <= MaxScannedRecords+1; i++ {
+		proposal, _ := baseProposalAndInput("2026-01-01T00:00:00Z")
+		proposal.ID = fmt.Sprintf("request-%04d", i)
+		if i == MaxScannedRecords+1 {
+			proposal.ChannelID = "otherchannel"
+		}
+		proposalJSON, err := json.Marshal(proposal)
+		require.NoError(t, err)
+		key, err := chaincodeStub.CreateCompositeKey(ProposalObjectType, []string{proposal.ID})
+		require.NoError(t, err)
+		require.NoError(t, chaincodeStub.PutState(key, proposalJSON))
+	}
+
+	// Case: The call stops scanning at the limit and returns the bookmark even if the page is not full
+	result, err := sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: MaxPageSize, ChannelID: "otherchannel"})
+	require.NoError(t, err)
+	require.Empty(t, result.Proposals)
+	require.Equal(t, fmt.Sprintf("proposal_request-%04d", MaxScannedRecords+1), result.Bookmark)
+
+	// Case: The next call continues scanning from the bookmark
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: MaxPageSize, ChannelID: "otherchannel", Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Len(t, result.Proposals, 1)
+	require.Equal(t, fmt.Sprintf("request-%04d", MaxScannedRecords+1), result.Proposals[0].ID)
+	require.Empty(t, result.Bookmark)
+}
+
+func TestQueryProposalsWithSelector(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	sc := SmartContract{}
+
+	expected, _ := baseProposalAndInput("")
+	expected.Status = Rejected
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Value: expectedJSON}, nil)
+	chaincodeStub.GetQueryResultWithPaginationReturns(iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: "next"}, nil)
+
+	// Case: Get proposals for the chaincode rejected after the given time
+	result, err := sc.QueryProposalsWithSelector(transactionContext, `{"chaincodeName": "basic", "status": "rejected", "time": {"$gte": "2026-01-01T00:00:00Z"}}`, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, &ProposalQueryResult{Proposals: []*ChaincodeUpdateProposal{&expected}, Bookmark: ""}, result)
+	query, pageSize, bookmark := chaincodeStub.GetQueryResultWithPaginationArgsForCall(0)
+	require.JSONEq(t, `{"selector": {"docType": "proposal", "chaincodeName": "basic", "status": "rejected", "time": {"$gte": "2026-01-01T00:00:00Z"}}}`, query)
+	require.Equal(t, int32(DefaultPageSize), pageSize)
+	require.Equal(t, "", bookmark)
+
+	// Case: Return the bookmark if the page is full
+	iterator.HasNextReturnsOnCall(2, true)
+	iterator.HasNextReturnsOnCall(3, false)
+	iterator.NextReturnsOnCall(1, &queryresult.KV{Value: expectedJSON}, nil)
+	result, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": {"$in": ["rejected", "withdrawn"]}}`, 1, "previous")
+	require.NoError(t, err)
+	require.Equal(t, "next", result.Bookmark)
+	query, pageSize, bookmark = chaincodeStub.GetQueryResultWithPaginationArgsForCall(1)
+	require.JSONEq(t, `{"selector": {"docType": "proposal", "status": {"$in": ["rejected", "withdrawn"]}}}`, query)
+	require.Equal(t, int32(1), pageSize)
+	require.Equal(t, "previous", bookmark)
+
+	// Case: Fail to get proposals with invalid selectors
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `[]`, 0, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the parameter 'selector' should be a JSON object")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"docType": "history"}`, 0, "")
+	require.EqualError(t, err, "the field 'docType' is not allowed in the selector")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"creator": "Org1MSP"}`, 0, "")
+	require.EqualError(t, err, "the selector should contain at least one of the fields: chaincodeName, channelID, status, time")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": {"$regex": "^re"}}`, 0, "")
+	require.EqualError(t, err, "the operator '$regex' is not allowed in the selector")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": {"$in": [{"$gt": ""}]}}`, 0, "")
+	require.EqualError(t, err, "the condition for the field 'status' is invalid")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": ["rejected"]}`, 0, "")
+	require.EqualError(t, err, "the condition for the field 'status' is invalid")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": "rejected"}`, MaxPageSize+1, "")
+	require.EqualError(t, err, "the parameter 'PageSize' should be between 1 and 100")
+	require.Equal(t, 2, chaincodeStub.GetQueryResultWithPaginationCallCount())
+
+	// Case: Fail to get proposals when the rich query is failed
+	chaincodeStub.GetQueryResultWithPaginationReturns(nil, nil, fmt.Errorf("rich query is not supported"))
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": "rejected"}`, 0, "")
+	require.EqualError(t, err, "error happened executing the rich query: rich query is not supported")
+}
+
+func TestQueryHistoriesWithSelector(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	sc := SmartContract{}
+
+	expected := History{
+		ObjectType: HistoryObjectType,
+		ProposalID: "request-1",
+		TaskID:     Vote,
+		OrgID:      "Org1MSP",
+		Status:     Agreed,
+		Time:       "2026-01-01T00:00:00Z",
+	}
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Value: expectedJSON}, nil)
+	chaincodeStub.GetQueryResultWithPaginationReturns(iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: 1}, nil)
+
+	// Case: Get the votes by the org
+	result, err := sc.QueryHistoriesWithSelector(transactionContext, `{"orgID": "Org1MSP", "taskID": "vote"}`, 10, "")
+	require.NoError(t, err)
+	require.Equal(t, &HistoryQueryResult{Histories: []*History{&expected}}, result)
+	query, _, _ := chaincodeStub.GetQueryResultWithPaginationArgsForCall(0)
+	require.JSONEq(t, `{"selector": {"docType": "history", "orgID": "Org1MSP", "taskID": "vote"}}`, query)
+
+	// Case: Fail to get histories with invalid selectors
+	_, err = sc.QueryHistoriesWithSelector(transactionContext, `{"status": "agreed"}`, 0, "")
+	require.EqualError(t, err, "the selector should contain at least one of the fields: orgID, proposalID, taskID")
+	_, err = sc.QueryHistoriesWithSelector(transactionContext, `{"orgID": "Org1MSP", "data": "secret"}`, 0, "")
+	require.EqualError(t, err, "the field 'data' is not allowed in the selector")
+
+	// Case: Fail to get histories when failed retrieving next item
+	iterator.HasNextReturns(true)
+	iterator.NextReturns(nil, fmt.Errorf("failed retrieving next item"))
+	_, err = sc.QueryHistoriesWithSelector(transactionContext, `{"orgID": "Org1MSP"}`, 0, "")
+	require.EqualError(t, err, "error happened iterating over available histories: failed retrieving next item")
+}
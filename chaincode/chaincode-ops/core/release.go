@@ -0,0 +1,666 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Functionalities to deploy interdependent chaincodes together as a release.
+// A release proposal bundles chaincode update proposals (members) for the chaincodes on one or more channels,
+// and the members are voted for once through the release.
+// The release can be voted for only by the orgs in any of the channels,
+// and it is approved when the votes meet the criteria in every channel.
+// After the release is approved, each member follows the same acknowledge and commit tasks as the other proposals,
+// except that no member is committed until all the members are acknowledged.
+
+// ReleaseProposal describes a proposal to deploy several chaincodes together that is stored as a state in the ledger.
+type ReleaseProposal struct {
+	ObjectType string   `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	ID         string   `json:"ID"`
+	Creator    string   `json:"creator"`
+	ChannelIDs []string `json:"channelIDs"` // the channels targeted by the members (sorted)
+	Members    []string `json:"members"`    // the IDs of the chaincode update proposals bundled by the release
+	Status     string   `json:"status"`
+	Time       string   `json:"time"`
+}
+
+// ReleaseProposalInput represents a request input of a new release proposal.
+type ReleaseProposalInput struct {
+	ID      string                         `json:"ID"`
+	Members []ChaincodeUpdateProposalInput `json:"members"`
+}
+
+// ReleaseDeploymentEventDetail represents details of ReleaseApprovedEvent and ReleaseDeployEvent.
+type ReleaseDeploymentEventDetail struct {
+	Release     ReleaseProposal         `json:"release"`
+	Deployments []DeploymentEventDetail `json:"deployments"` // the deployments of the members (the same as the details of PrepareToDeployEvent or DeployEvent)
+}
+
+// Object types
+const (
+	ReleaseProposalObjectType = "releaseProposal"
+)
+
+// Chaincode event names for release proposals
+const (
+	NewReleaseProposalEvent = "newReleaseProposalEvent"
+	NewReleaseVoteEvent     = "newReleaseVoteEvent"
+	ReleaseApprovedEvent    = "releaseApprovedEvent"
+	ReleaseDeployEvent      = "releaseDeployEvent"
+	ReleaseRejectedEvent    = "releaseRejectedEvent"
+	ReleaseWithdrawnEvent   = "releaseWithdrawnEvent"
+)
+
+// Task IDs for release proposals
+const (
+	ReleaseVote = "releaseVote"
+)
+
+var (
+	// ErrNotEligibleVoter is returned when the org which is not in any of the channels of the release votes for the release.
+	ErrNotEligibleVoter = fmt.Errorf("only the orgs in the channels of the release can vote for it")
+)
+
+// RequestReleaseProposal requests a new release proposal which bundles the chaincode update proposals for the member chaincodes.
+// The members are validated in the same way as RequestProposal, and they can target different channels.
+// The voting deadline and the deployment window are not available for the members.
+//
+// Arguments:
+//   0: input - the request input for the release proposal
+//
+// Returns:
+//   0: the created release proposal
+//   1: error
+//
+// Events:
+//   (if the request can be approved without any other votes)
+//   name: releaseApprovedEvent(<releaseID>)
+//   payload: ReleaseDeploymentEventDetail
+//   (else)
+//   name: newReleaseProposalEvent(<releaseID>)
+//   payload: the created release proposal
+//
+func (s *SmartContract) RequestReleaseProposal(ctx contractapi.TransactionContextInterface, input ReleaseProposalInput) (*ReleaseProposal, error) {
+
+	if err := s.authorize(ctx, "RequestReleaseProposal"); err != nil {
+		return nil, err
+	}
+	return s.requestReleaseProposal(ctx, input)
+}
+
+// requestReleaseProposal requests a new release proposal without the authorization of the caller,
+// which should be done by the func called by the client.
+func (s *SmartContract) requestReleaseProposal(ctx contractapi.TransactionContextInterface, input ReleaseProposalInput) (*ReleaseProposal, error) {
+
+	// Validate input
+	if input.ID == "" {
+		return nil, fmt.Errorf("the required parameter proposal 'ID' is empty")
+	}
+
+	if len(input.Members) < 2 {
+		return nil, fmt.Errorf("the parameter 'Members' should have two or more chaincode update proposals")
+	}
+
+	// Build the release
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	release := ReleaseProposal{
+		ObjectType: ReleaseProposalObjectType,
+		ID:         input.ID,
+		Creator:    mspID,
+		ChannelIDs: []string{},
+		Members:    []string{},
+		Status:     Proposed,
+		Time:       txTimestamp,
+	}
+
+	// Fail if the release with the ID already exists
+	if r, _ := s.GetReleaseProposal(ctx, input.ID); r != nil {
+		return nil, ErrProposalIDAreadyInUse
+	}
+
+	// Build the members
+	// (the states put in this transaction cannot be read in it, so the duplicates among the members are checked here)
+	members := []ChaincodeUpdateProposal{}
+	memberIDs := map[string]bool{}
+	chaincodes := map[string]bool{}
+	for i, memberInput := range input.Members {
+		fields := []struct {
+			name  string
+			value string
+		}{
+			{"VotingDeadline", memberInput.VotingDeadline},
+			{"NotBefore", memberInput.NotBefore},
+			{"NotAfter", memberInput.NotAfter},
+		}
+		for _, field := range fields {
+			if field.value != "" {
+				return nil, fmt.Errorf("the parameter 'Members[%d].%s' is not available for the members of a release", i, field.name)
+			}
+		}
+
+		if len(memberInput.DependsOn) > 0 {
+			return nil, fmt.Errorf("the parameter 'Members[%d].DependsOn' is not available for the members of a release", i)
+		}
+
+		member, err := s.newProposal(ctx, memberInput, "")
+		if err != nil {
+			return nil, fmt.Errorf("the parameter 'Members[%d]' is invalid: %v", i, err)
+		}
+		if memberIDs[member.ID] {
+			return nil, fmt.Errorf("the proposal ID %s is duplicated in the members", member.ID)
+		}
+		chaincode := member.ChannelID + "/" + member.ChaincodeName
+		if chaincodes[chaincode] {
+			return nil, fmt.Errorf("the chaincode %s on the channel %s is duplicated in the members", member.ChaincodeName, member.ChannelID)
+		}
+		memberIDs[member.ID] = true
+		chaincodes[chaincode] = true
+
+		member.ReleaseID = release.ID
+		members = append(members, *member)
+		release.Members = append(release.Members, member.ID)
+		if !contains(release.ChannelIDs, member.ChannelID) {
+			release.ChannelIDs = append(release.ChannelIDs, member.ChannelID)
+		}
+	}
+	sort.Strings(release.ChannelIDs)
+
+	// Fail if the proposer is not in any of the channels
+	if err = s.checkEligibleVoter(ctx, release, mspID); err != nil {
+		return nil, err
+	}
+
+	// Put the release and the members to stateDB
+	if err = s.putReleaseProposal(ctx, release); err != nil {
+		return nil, fmt.Errorf("failed to put the proposal: %v", err)
+	}
+	for _, member := range members {
+		if err = s.putProposal(ctx, &member); err != nil {
+			return nil, fmt.Errorf("failed to put the proposal: %v", err)
+		}
+	}
+
+	// Vote for myself
+	history, err := s.putHistory(ctx, release.ID, ReleaseVote, Agreed, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put the history that the org votes for: %v", err)
+	}
+
+	// If the vote from this organization alone meets the MAJORITY condition in every channel, approve the release and the members immediately
+	votePassed, err := s.meetCriteriaInChannels(ctx, *history, MAJORITY, false, release.ChannelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do meetCriteria: %v", err)
+	}
+	if votePassed {
+		if err = s.approveRelease(ctx, &release, members); err != nil {
+			return nil, fmt.Errorf("failed to update the status: %v", err)
+		}
+		return &release, nil
+	}
+
+	// Else issue NewReleaseProposalEvent
+	if err = s.setReleaseEvent(ctx, NewReleaseProposalEvent, release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// VoteForReleaseProposal votes for / against the release proposal.
+// This only accepts the vote from the orgs in any of the channels of the release.
+// This function records the vote as a state into the ledger.
+// Also, if the release is voted by MAJORITY in every channel, this changes the status of the release and all its members from proposed to approved.
+// If MAJORITY becomes unachievable in any channel, this changes them to rejected.
+//
+// Arguments:
+//   0: taskStatusUpdateRequest - the request input for voting for/against the release proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   (if the status is changed to approved)
+//   name: releaseApprovedEvent(<releaseID>)
+//   payload: ReleaseDeploymentEventDetail
+//   (if the status is changed to rejected)
+//   name: releaseRejectedEvent(<releaseID>)
+//   payload: the rejected release proposal
+//   (else)
+//   name: newReleaseVoteEvent(<releaseID>)
+//   payload: nil
+//
+func (s *SmartContract) VoteForReleaseProposal(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
+
+	if err := s.authorize(ctx, "VoteForReleaseProposal"); err != nil {
+		return err
+	}
+
+	// Set default values
+	if taskStatusUpdateRequest.Status == "" {
+		taskStatusUpdateRequest.Status = Agreed
+	}
+
+	// Validate input
+	if taskStatusUpdateRequest.ProposalID == "" {
+		return fmt.Errorf("the required parameter 'ProposalID' is empty")
+	}
+
+	if taskStatusUpdateRequest.Status != Agreed && taskStatusUpdateRequest.Status != Disagreed {
+		return fmt.Errorf("task status for vote should be %s or %s", Agreed, Disagreed)
+	}
+
+	if taskStatusUpdateRequest.OnBehalfOf != "" {
+		return ErrDelegatedVoteNotAvailable
+	}
+
+	// Get release from StateDB
+	release, err := s.GetReleaseProposal(ctx, taskStatusUpdateRequest.ProposalID)
+	if err != nil {
+		return fmt.Errorf("failed to get the proposal: %v", err)
+	}
+	// If the release status already got changed from "Proposed", return error
+	if release.Status != Proposed {
+		return fmt.Errorf("the voting is already closed")
+	}
+
+	// If the org is not in any of the channels, return error
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	if err = s.checkEligibleVoter(ctx, *release, mspID); err != nil {
+		return err
+	}
+
+	// Put the task status as a history to stateDB
+	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, ReleaseVote, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, false)
+	if err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
+	}
+
+	// [State Transition]
+	// The same as Vote() for chaincode update proposals except that the status of the members is changed together
+	switch taskStatusUpdateRequest.Status {
+	case Agreed:
+		votePassed, err := s.meetCriteriaInChannels(ctx, *history, MAJORITY, false, release.ChannelIDs)
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if votePassed {
+			members, err := s.getReleaseMembers(ctx, *release, nil)
+			if err != nil {
+				return err
+			}
+			if err = s.approveRelease(ctx, release, members); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return nil
+		}
+	case Disagreed:
+		voteRejected, err := s.meetCriteriaInChannels(ctx, *history, MAJORITY, true, release.ChannelIDs)
+		if err != nil {
+			return fmt.Errorf("failed to do meetCriteria: %v", err)
+		}
+		if voteRejected {
+			if err = s.closeRelease(ctx, release, Rejected); err != nil {
+				return fmt.Errorf("failed to update the status: %v", err)
+			}
+			return s.setReleaseEvent(ctx, ReleaseRejectedEvent, *release)
+		}
+	}
+	if err := s.setEvent(ctx, NewReleaseVoteEvent, release.ID, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WithdrawReleaseProposal withdraws the release proposal and all its members.
+// This only accepts the request from the proposing organization.
+// This function is only available before the decision of the release.
+//
+// Arguments:
+//   0: proposalID - the ID for the release proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   name: releaseWithdrawnEvent(<releaseID>)
+//   payload: the withdrawn release proposal
+//
+func (s *SmartContract) WithdrawReleaseProposal(ctx contractapi.TransactionContextInterface, proposalID string) error {
+
+	if err := s.authorize(ctx, "WithdrawReleaseProposal"); err != nil {
+		return err
+	}
+
+	// Validate input
+	if proposalID == "" {
+		return fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	// Get release from StateDB
+	release, err := s.GetReleaseProposal(ctx, proposalID)
+	if err != nil {
+		return ErrProposalNotFound
+	}
+
+	// If the release status already got changed from "Proposed", return error
+	if release.Status != Proposed {
+		return fmt.Errorf("the voting is already closed")
+	}
+
+	// If the release is not created by the requester, return error
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	if release.Creator != mspID {
+		return fmt.Errorf("only the proposer (%v) can withdraw the proposal", release.Creator)
+	}
+
+	if err = s.closeRelease(ctx, release, Withdrawn); err != nil {
+		return fmt.Errorf("failed to update the status: %v", err)
+	}
+	return s.setReleaseEvent(ctx, ReleaseWithdrawnEvent, *release)
+}
+
+// GetReleaseProposal returns the release proposal with the given ID.
+//
+// Arguments:
+//   0: proposalID - the ID of the release proposal
+//
+// Returns:
+//   0: the release proposal with the given ID
+//   1: error
+//
+func (s *SmartContract) GetReleaseProposal(ctx contractapi.TransactionContextInterface, proposalID string) (*ReleaseProposal, error) {
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ReleaseProposalObjectType, []string{proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened creating composite key for proposal: %v", err)
+	}
+
+	releaseJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading proposal with id (%v): %v", proposalID, err)
+	}
+
+	if releaseJSON == nil {
+		return nil, ErrProposalNotFound
+	}
+
+	var release ReleaseProposal
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+	}
+	return &release, nil
+}
+
+// GetAllReleaseProposals returns the all release proposals.
+//
+// Arguments: none
+//
+// Returns:
+//   0: the map of the all release proposals
+//   1: error
+//
+func (s *SmartContract) GetAllReleaseProposals(ctx contractapi.TransactionContextInterface) (map[string]*ReleaseProposal, error) {
+
+	releases := make(map[string]*ReleaseProposal)
+	releaseIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(ReleaseProposalObjectType, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer releaseIterator.Close()
+
+	for releaseIterator.HasNext() {
+		releaseJSON, err := releaseIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available proposals: %v", err)
+		}
+		release := &ReleaseProposal{}
+		if err = json.Unmarshal(releaseJSON.Value, release); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+		}
+		releases[releaseJSON.Key] = release
+	}
+	return releases, nil
+}
+
+// -- Internal logics
+
+// approveRelease changes the status of the release and all its members to approved, and issues ReleaseApprovedEvent.
+func (s *SmartContract) approveRelease(ctx contractapi.TransactionContextInterface, release *ReleaseProposal, members []ChaincodeUpdateProposal) error {
+	release.Status = Approved
+	if err := s.putReleaseProposal(ctx, *release); err != nil {
+		return err
+	}
+
+	operationTargets := map[string][]string{}
+	deployments := []DeploymentEventDetail{}
+	for _, member := range members {
+		member.Status = Approved
+		if err := s.putProposal(ctx, &member); err != nil {
+			return err
+		}
+		if _, ok := operationTargets[member.ChannelID]; !ok {
+			orgs, err := s.getOrganizationsInChannel(ctx, member.ChannelID)
+			if err != nil {
+				return err
+			}
+			operationTargets[member.ChannelID] = orgs
+		}
+		deployments = append(deployments, DeploymentEventDetail{
+			Proposal:         member,
+			OperationTargets: operationTargets[member.ChannelID],
+		})
+	}
+	return s.setReleaseDeploymentEvent(ctx, ReleaseApprovedEvent, *release, deployments)
+}
+
+// closeRelease changes the status of the release and all its members to the given status (rejected or withdrawn).
+func (s *SmartContract) closeRelease(ctx contractapi.TransactionContextInterface, release *ReleaseProposal, status string) error {
+	members, err := s.getReleaseMembers(ctx, *release, nil)
+	if err != nil {
+		return err
+	}
+	release.Status = status
+	if err := s.putReleaseProposal(ctx, *release); err != nil {
+		return err
+	}
+	for _, member := range members {
+		member.Status = status
+		if err := s.putProposal(ctx, &member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// meetCriteriaInChannels checks whether the votes from the orgs in each channel meet the criteria in every channel.
+// If checkUnachievable is true, this checks whether the votes cannot meet the criteria in any channel instead.
+func (s *SmartContract) meetCriteriaInChannels(ctx contractapi.TransactionContextInterface, currentHistory History, criteria string, checkUnachievable bool, channelIDs []string) (bool, error) {
+	for _, channelID := range channelIDs {
+		met, err := s.meetCriteriaWithVoters(ctx, currentHistory, criteria, checkUnachievable, channelID, true)
+		if err != nil {
+			return false, err
+		}
+		if met == checkUnachievable {
+			return met, nil
+		}
+	}
+	return !checkUnachievable, nil
+}
+
+// checkEligibleVoter fails if the org is not in any of the channels of the release.
+func (s *SmartContract) checkEligibleVoter(ctx contractapi.TransactionContextInterface, release ReleaseProposal, mspID string) error {
+	for _, channelID := range release.ChannelIDs {
+		orgs, err := s.getOrganizationsInChannel(ctx, channelID)
+		if err != nil {
+			return err
+		}
+		if contains(orgs, mspID) {
+			return nil
+		}
+	}
+	return ErrNotEligibleVoter
+}
+
+// deployReleaseIfAcknowledged issues ReleaseDeployEvent for the acknowledged members of the release
+// if all the members of the release which bundles the given member are acknowledged (or already committed).
+// Otherwise, the given member waits for the others without any event.
+func (s *SmartContract) deployReleaseIfAcknowledged(ctx contractapi.TransactionContextInterface, member ChaincodeUpdateProposal, notAcknowledgedOrgs []string) error {
+	release, err := s.syncReleaseStatus(ctx, member)
+	if err != nil {
+		return err
+	}
+	if release.Status != Acknowledged {
+		return nil
+	}
+
+	members, err := s.getReleaseMembers(ctx, *release, &member)
+	if err != nil {
+		return err
+	}
+	deployments := []DeploymentEventDetail{}
+	for _, m := range members {
+		if m.Status != Acknowledged {
+			continue
+		}
+		orgs := notAcknowledgedOrgs
+		if m.ID != member.ID {
+			if _, orgs, err = s.getNotAcknowledgedOrgs(ctx, m, ""); err != nil {
+				return fmt.Errorf("failed to get the orgs which have not acknowledged: %v", err)
+			}
+		}
+		deployments = append(deployments, DeploymentEventDetail{
+			Proposal:            m,
+			OperationTargets:    m.OperationTargets,
+			NotAcknowledgedOrgs: orgs,
+		})
+	}
+	return s.setReleaseDeploymentEvent(ctx, ReleaseDeployEvent, *release, deployments)
+}
+
+// syncReleaseStatus updates the status of the approved release which bundles the given member based on the statuses of all the members,
+// and returns the release (nil if the proposal is not a member of a release).
+//   - committed: all the members are committed
+//   - failed: any member is failed
+//   - acknowledged: all the members are acknowledged or committed
+//   - approved: others
+func (s *SmartContract) syncReleaseStatus(ctx contractapi.TransactionContextInterface, member ChaincodeUpdateProposal) (*ReleaseProposal, error) {
+	if member.ReleaseID == "" {
+		return nil, nil
+	}
+	release, err := s.GetReleaseProposal(ctx, member.ReleaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the release: %v", err)
+	}
+	if release.Status != Approved && release.Status != Acknowledged && release.Status != Failed {
+		return release, nil
+	}
+
+	members, err := s.getReleaseMembers(ctx, *release, &member)
+	if err != nil {
+		return nil, err
+	}
+	status := Approved
+	acknowledged, committed := 0, 0
+	for _, m := range members {
+		switch m.Status {
+		case Failed:
+			status = Failed
+		case Acknowledged:
+			acknowledged++
+		case Committed:
+			committed++
+		}
+	}
+	if status != Failed {
+		if committed == len(members) {
+			status = Committed
+		} else if acknowledged+committed == len(members) {
+			status = Acknowledged
+		}
+	}
+
+	if status == release.Status {
+		return release, nil
+	}
+	release.Status = status
+	if err := s.putReleaseProposal(ctx, *release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// getReleaseMembers returns the members of the release in the order of the request.
+// If updated is not nil, it is used instead of the member with the same ID in stateDB
+// (the states put in the current transaction cannot be read in it).
+func (s *SmartContract) getReleaseMembers(ctx contractapi.TransactionContextInterface, release ReleaseProposal, updated *ChaincodeUpdateProposal) ([]ChaincodeUpdateProposal, error) {
+	members := []ChaincodeUpdateProposal{}
+	for _, memberID := range release.Members {
+		if updated != nil && updated.ID == memberID {
+			members = append(members, *updated)
+			continue
+		}
+		member, err := s.GetProposal(ctx, memberID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the member (%s): %v", memberID, err)
+		}
+		members = append(members, *member)
+	}
+	return members, nil
+}
+
+func (s *SmartContract) setReleaseEvent(ctx contractapi.TransactionContextInterface, eventName string, release ReleaseProposal) error {
+	return s.setEvent(ctx, eventName, release.ID, release)
+}
+
+func (s *SmartContract) setReleaseDeploymentEvent(ctx contractapi.TransactionContextInterface, eventName string, release ReleaseProposal, deployments []DeploymentEventDetail) error {
+	return s.setEvent(ctx, eventName, release.ID, ReleaseDeploymentEventDetail{
+		Release:     release,
+		Deployments: deployments,
+	})
+}
+
+func (s *SmartContract) putReleaseProposal(ctx contractapi.TransactionContextInterface, release ReleaseProposal) error {
+	// Create composite key
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(ReleaseProposalObjectType, []string{release.ID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for proposal: %v", err)
+	}
+
+	// struct to JSON
+	releaseJSON, err := json.Marshal(release)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the new proposal: %v", err)
+	}
+
+	// Put release to StateDB
+	err = ctx.GetStub().PutState(compositeKey, releaseJSON)
+	if err != nil {
+		return fmt.Errorf("error happened persisting the new proposal on the ledger: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseProposal(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	memberInput := func(memberID string) ChaincodeUpdateProposalInput {
+		_, input := baseProposalAndInput("")
+		input.ID = memberID
+		input.ChaincodeName = memberID
+		return input
+	}
+	requestRelease := func(releaseID string, memberIDs ...string) (*ReleaseProposal, error) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		input := ReleaseProposalInput{ID: releaseID}
+		for _, memberID := range memberIDs {
+			input.Members = append(input.Members, memberInput(memberID))
+		}
+		return sc.RequestReleaseProposal(transactionContext, input)
+	}
+	requireStatus := func(releaseID string, status string, memberStatus string) {
+		release, err := sc.GetReleaseProposal(transactionContext, releaseID)
+		require.NoError(t, err)
+		require.Equal(t, status, release.Status)
+		for _, memberID := range release.Members {
+			member, err := sc.GetProposal(transactionContext, memberID)
+			require.NoError(t, err)
+			require.Equal(t, memberStatus, member.Status, memberID)
+		}
+	}
+	requireDeploymentEvent := func(eventName string, memberIDs []string, operationTargets []string) {
+		actualEventName, eventPayload := lastEvent(chaincodeStub)
+		require.Equal(t, eventName, actualEventName)
+		var eventDetail ReleaseDeploymentEventDetail
+		require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+		require.Len(t, eventDetail.Deployments, len(memberIDs))
+		for i, deployment := range eventDetail.Deployments {
+			require.Equal(t, memberIDs[i], deployment.Proposal.ID)
+			require.Equal(t, eventDetail.Release.ID, deployment.Proposal.ReleaseID)
+			require.Equal(t, operationTargets, deployment.OperationTargets)
+		}
+	}
+	acknowledge := func(creator []byte, request TaskStatusUpdateRequest) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		require.NoError(t, sc.Acknowledge(transactionContext, request))
+	}
+	notifyCommitResult := func(creator []byte, memberID string) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		require.NoError(t, sc.NotifyCommitResult(transactionContext, TaskStatusUpdateRequest{ProposalID: memberID}))
+	}
+
+	// Case: Request a release proposal
+	release, err := requestRelease("release-1", "member-1", "member-2")
+	require.NoError(t, err)
+	require.Equal(t, []string{"mychannel"}, release.ChannelIDs)
+	require.Equal(t, []string{"member-1", "member-2"}, release.Members)
+	requireStatus("release-1", Proposed, Proposed)
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "newReleaseProposalEvent.release-1", eventName)
+
+	member, err := sc.GetProposal(transactionContext, "member-1")
+	require.NoError(t, err)
+	require.Equal(t, "release-1", member.ReleaseID)
+
+	// Case: The members cannot be voted for or withdrawn individually
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.ErrorIs(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "member-1"}), ErrReleaseMember)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.ErrorIs(t, sc.WithdrawProposal(transactionContext, "member-1"), ErrReleaseMember)
+
+	// Case: The release and all the members are approved by a single vote
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-1"}))
+	requireStatus("release-1", Approved, Approved)
+	requireDeploymentEvent("releaseApprovedEvent.release-1", []string{"member-1", "member-2"}, []string{"Org1MSP", "Org2MSP"})
+
+	// Case: The acknowledged member waits for the other members without any event
+	setEventCallCount := chaincodeStub.SetEventCallCount()
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "member-1"})
+	acknowledge(org2MSP, TaskStatusUpdateRequest{ProposalID: "member-1"})
+	require.Equal(t, setEventCallCount, chaincodeStub.SetEventCallCount())
+	member, err = sc.GetProposal(transactionContext, "member-1")
+	require.NoError(t, err)
+	require.Equal(t, Acknowledged, member.Status)
+	release, err = sc.GetReleaseProposal(transactionContext, "release-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, release.Status)
+
+	// Case: All the members are deployed together after all of them are acknowledged
+	acknowledge(org2MSP, TaskStatusUpdateRequest{ProposalID: "member-2"})
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "member-2"})
+	requireStatus("release-1", Acknowledged, Acknowledged)
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "releaseDeployEvent.release-1", eventName)
+	var eventDetail ReleaseDeploymentEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Equal(t, Acknowledged, eventDetail.Release.Status)
+	require.Equal(t, []string{"Org2MSP"}, eventDetail.Deployments[0].OperationTargets)
+	require.Equal(t, []string{"Org1MSP"}, eventDetail.Deployments[1].OperationTargets)
+
+	// Case: The release is committed only after all the members are committed
+	notifyCommitResult(org2MSP, "member-1")
+	release, err = sc.GetReleaseProposal(transactionContext, "release-1")
+	require.NoError(t, err)
+	require.Equal(t, Acknowledged, release.Status)
+	notifyCommitResult(org1MSP, "member-2")
+	requireStatus("release-1", Committed, Committed)
+
+	histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: "member-2", TaskID: Commit})
+	require.NoError(t, err)
+	require.Len(t, histories, 1)
+	for _, history := range histories {
+		require.Equal(t, "Org1MSP", history.OrgID)
+	}
+
+	// Case: The release is failed when any member is failed, and it is approved again by retrying the member
+	_, err = requestRelease("release-2", "member-3", "member-4")
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-2"}))
+	acknowledge(org1MSP, TaskStatusUpdateRequest{ProposalID: "member-3", Status: Failure})
+	release, err = sc.GetReleaseProposal(transactionContext, "release-2")
+	require.NoError(t, err)
+	require.Equal(t, Failed, release.Status)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "failedEvent.member-3", eventName)
+
+	require.NoError(t, sc.RetryProposal(transactionContext, "member-3"))
+	requireStatus("release-2", Approved, Approved)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "prepareToDeployEvent.member-3", eventName)
+
+	// Case: The release and all the members are rejected
+	_, err = requestRelease("release-3", "member-5", "member-6")
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-3", Status: Disagreed}))
+	requireStatus("release-3", Rejected, Rejected)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "releaseRejectedEvent.release-3", eventName)
+	require.EqualError(t, sc.VoteForReleaseProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "release-3"}), "the voting is already closed")
+
+	// Case: The release and all the members are withdrawn by the proposer, and the chaincodes can be proposed again
+	_, err = requestRelease("release-4", "member-7", "member-8")
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.EqualError(t, sc.WithdrawReleaseProposal(transactionContext, "release-4"), "only the proposer (Org1MSP) can withdraw the proposal")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.WithdrawReleaseProposal(transactionContext, "release-4"))
+	requireStatus("release-4", Withdrawn, Withdrawn)
+
+	input := memberInput("member-9")
+	input.ChaincodeName = "member-7"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+
+	releases, err := sc.GetAllReleaseProposals(transactionContext)
+	require.NoError(t, err)
+	require.Len(t, releases, 4)
+}
+
+func TestRequestReleaseProposalWithInvalidInput(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	_, base := baseProposalAndInput("")
+	member := func(memberID string, chaincodeName string, modify func(*ChaincodeUpdateProposalInput)) ChaincodeUpdateProposalInput {
+		input := base
+		input.ID = memberID
+		input.ChaincodeName = chaincodeName
+		if modify != nil {
+			modify(&input)
+		}
+		return input
+	}
+
+	existing := member("existing", "existing", nil)
+	_, err := sc.RequestProposal(transactionContext, existing)
+	require.NoError(t, err)
+	_, err = sc.RequestReleaseProposal(transactionContext, ReleaseProposalInput{ID: "existing-release", Members: []ChaincodeUpdateProposalInput{member("a", "a", nil), member("b", "b", nil)}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       ReleaseProposalInput
+		expectedErr string
+	}{
+		{
+			name:        "empty ID",
+			input:       ReleaseProposalInput{Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "d", nil)}},
+			expectedErr: "the required parameter proposal 'ID' is empty",
+		},
+		{
+			name:        "single member",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil)}},
+			expectedErr: "the parameter 'Members' should have two or more chaincode update proposals",
+		},
+		{
+			name:        "ID in use",
+			input:       ReleaseProposalInput{ID: "existing-release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "d", nil)}},
+			expectedErr: "proposalID already in use",
+		},
+		{
+			name:        "invalid member",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "", nil)}},
+			expectedErr: "the parameter 'Members[1]' is invalid: the required parameter 'ChaincodeName' is empty",
+		},
+		{
+			name:        "member for the chaincode with an open proposal",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "existing", nil)}},
+			expectedErr: "the parameter 'Members[1]' is invalid: the chaincode already has an open proposal (existing)",
+		},
+		{
+			name:        "member with the ID in use",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("existing", "c", nil), member("d", "d", nil)}},
+			expectedErr: "the parameter 'Members[0]' is invalid: proposalID already in use",
+		},
+		{
+			name:        "duplicated member ID",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("c", "d", nil)}},
+			expectedErr: "the proposal ID c is duplicated in the members",
+		},
+		{
+			name:        "duplicated chaincode",
+			input:       ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "c", nil)}},
+			expectedErr: "the chaincode c on the channel mychannel is duplicated in the members",
+		},
+		{
+			name: "member with deployment window",
+			input: ReleaseProposalInput{ID: "release", Members: []ChaincodeUpdateProposalInput{member("c", "c", nil), member("d", "d", func(input *ChaincodeUpdateProposalInput) {
+				input.NotBefore = time.Now().Add(time.Hour).Format(time.RFC3339)
+			})}},
+			expectedErr: "the parameter 'Members[1].NotBefore' is not available for the members of a release",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sc.RequestReleaseProposal(transactionContext, tt.input)
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// RequestRollbackProposal requests a new chaincode update proposal to restore the chaincode definition committed by an earlier proposal.
+// The new proposal clones the chaincode package and definition of the target proposal with the next sequence
+// computed from the committed proposals recorded in chaincode-ops.
+// The created proposal follows the same vote flow as the other chaincode update proposals.
+// The ID of the created proposal has the transaction ID, so that the rollback can be requested again
+// after the previous rollback proposal is rejected, withdrawn or expired.
+//
+// Arguments:
+//   0: channelID - the channel ID of the chaincode to be rolled back
+//   1: chaincodeName - the name of the chaincode to be rolled back
+//   2: targetProposalID - the ID of the committed proposal to be restored
+//
+// Returns:
+//   0: the created proposal (the ID is "rollback-<targetProposalID>-<sequence>-<txID>")
+//   1: error
+//
+// Events:
+//   the same as RequestProposal()
+//
+func (s *SmartContract) RequestRollbackProposal(ctx contractapi.TransactionContextInterface, channelID string, chaincodeName string, targetProposalID string) (*ChaincodeUpdateProposal, error) {
+
+	if err := s.authorize(ctx, "RequestRollbackProposal"); err != nil {
+		return nil, err
+	}
+
+	// Validate input
+	if channelID == "" {
+		return nil, fmt.Errorf("the required parameter 'channelID' is empty")
+	}
+
+	if chaincodeName == "" {
+		return nil, fmt.Errorf("the required parameter 'chaincodeName' is empty")
+	}
+
+	if targetProposalID == "" {
+		return nil, fmt.Errorf("the required parameter 'targetProposalID' is empty")
+	}
+
+	// Get the target proposal from StateDB (or the archive)
+	target, err := s.getProposalOrArchived(ctx, targetProposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the target proposal: %v", err)
+	}
+
+	if target.ChannelID != channelID || target.ChaincodeName != chaincodeName {
+		return nil, fmt.Errorf("the target proposal is not for the chaincode (name: %s, channel: %s)", chaincodeName, channelID)
+	}
+
+	if target.Status != Committed {
+		return nil, fmt.Errorf("the target proposal is not committed")
+	}
+
+	// Compute the next sequence
+	state, err := s.getChaincodeState(ctx, channelID, chaincodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the chaincode state: %v", err)
+	}
+	lastSequence := state.LastCommittedSequence
+
+	if target.ChaincodeDefinition.Sequence == lastSequence {
+		return nil, fmt.Errorf("the target proposal has the currently committed chaincode definition")
+	}
+
+	// Clone the chaincode package and definition of the target proposal
+	definition := target.ChaincodeDefinition
+	definition.Sequence = lastSequence + 1
+
+	input := ChaincodeUpdateProposalInput{
+		ID:                  fmt.Sprintf("rollback-%s-%d-%s", target.ID, definition.Sequence, ctx.GetStub().GetTxID()),
+		ChannelID:           channelID,
+		ChaincodeName:       chaincodeName,
+		ChaincodePackage:    target.ChaincodePackage,
+		ChaincodeDefinition: definition,
+	}
+
+	return s.requestProposal(ctx, input, target.ID)
+}
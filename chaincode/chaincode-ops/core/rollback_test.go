@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestRollbackProposal(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// Prepare the committed proposals with sequence 1 and 2
+	_, input := baseProposalAndInput("")
+	deployProposal(t, sc, transactionContext, chaincodeStub, input)
+
+	_, input = baseProposalAndInput("")
+	input.ID = "request-2"
+	input.ChaincodePackage.CommitID = "broken"
+	input.ChaincodeDefinition.Sequence = 2
+	deployProposal(t, sc, transactionContext, chaincodeStub, input)
+
+	// Case: Fail to request when the target proposal has the currently committed definition
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err := sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "request-2")
+	require.EqualError(t, err, "the target proposal has the currently committed chaincode definition")
+
+	// Case: Request a rollback proposal to restore the definition committed by request-1
+	chaincodeStub.GetTxIDReturns("tx-1")
+	proposal, err := sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "request-1")
+	require.NoError(t, err)
+	require.Equal(t, "rollback-request-1-3-tx-1", proposal.ID)
+	require.Equal(t, "request-1", proposal.RollbackOf)
+	require.Equal(t, Proposed, proposal.Status)
+	require.Equal(t, "main", proposal.ChaincodePackage.CommitID)
+	require.Equal(t, int64(3), proposal.ChaincodeDefinition.Sequence)
+
+	stored, err := sc.GetProposal(transactionContext, "rollback-request-1-3-tx-1")
+	require.NoError(t, err)
+	require.Equal(t, proposal, stored)
+
+	eventName, _ := lastEvent(chaincodeStub)
+	require.Equal(t, "newProposalEvent.rollback-request-1-3-tx-1", eventName)
+
+	// Case: Fail to request the same rollback proposal while the previous one is open
+	chaincodeStub.GetTxIDReturns("tx-2")
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "request-1")
+	require.EqualError(t, err, "the chaincode already has an open proposal (rollback-request-1-3-tx-1)")
+
+	// Case: Request the rollback again after the previous one is withdrawn
+	require.NoError(t, sc.WithdrawProposal(transactionContext, "rollback-request-1-3-tx-1"))
+	chaincodeStub.GetTxIDReturns("tx-3")
+	proposal, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "request-1")
+	require.NoError(t, err)
+	require.Equal(t, "rollback-request-1-3-tx-3", proposal.ID)
+	require.Equal(t, "request-1", proposal.RollbackOf)
+	require.Equal(t, int64(3), proposal.ChaincodeDefinition.Sequence)
+
+	// Case: Fail to request when the target is for another chaincode
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "other", "request-1")
+	require.EqualError(t, err, "the target proposal is not for the chaincode (name: other, channel: mychannel)")
+
+	// Case: Fail to request when the target is not committed
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "rollback-request-1-3-tx-3")
+	require.EqualError(t, err, "the target proposal is not committed")
+
+	// Case: Fail to request when the target does not exist
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "request-9")
+	require.EqualError(t, err, "failed to get the target proposal: proposal not found")
+
+	// Case: Fail to request when the parameters are empty
+	_, err = sc.RequestRollbackProposal(transactionContext, "", "basic", "request-1")
+	require.EqualError(t, err, "the required parameter 'channelID' is empty")
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "", "request-1")
+	require.EqualError(t, err, "the required parameter 'chaincodeName' is empty")
+	_, err = sc.RequestRollbackProposal(transactionContext, "mychannel", "basic", "")
+	require.EqualError(t, err, "the required parameter 'targetProposalID' is empty")
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ReleasedEventDetail represents details of ReleasedEvent.
+type ReleasedEventDetail struct {
+	Deployments        []DeploymentEventDetail `json:"deployments"`                                       // the released proposals and their operation targets (the same as the details of PrepareToDeployEvent)
+	ExpiredProposals   []string                `json:"expiredProposals"`                                  // the IDs of the proposals expired because their deployment windows have closed
+	ScheduledProposals []string                `json:"scheduledProposals,omitempty" metadata:",optional"` // the IDs of the proposals held until their deployment windows open (only for ReleaseDependentProposals)
+	RejectedProposals  []string                `json:"rejectedProposals,omitempty" metadata:",optional"`  // the IDs of the proposals rejected because their dependencies can no longer be committed (only for ReleaseDependentProposals)
+}
+
+// ReleaseScheduledProposals releases the scheduled proposals whose deployment window has opened.
+// This changes the status of the proposals from scheduled to approved, so that the agents start to prepare the deployment.
+// The scheduled proposals whose deployment window has closed are changed to expired instead.
+// Whether the window is open is judged by the timestamp of the transaction.
+//
+// Arguments: none
+//
+// Returns:
+//   0: the list of the IDs of the released proposals
+//   1: error
+//
+// Events:
+//   (if one or more proposals are released or expired)
+//   name: releasedEvent
+//   payload: ReleasedEventDetail
+//   (NOTE: a transaction can only have one chaincode event, so the event does not have the proposal ID suffix)
+//
+func (s *SmartContract) ReleaseScheduledProposals(ctx contractapi.TransactionContextInterface) ([]string, error) {
+
+	if err := s.authorize(ctx, "ReleaseScheduledProposals"); err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.getProposalsByStatus(ctx, Scheduled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposals: %v", err)
+	}
+
+	eventDetail := ReleasedEventDetail{
+		Deployments:      []DeploymentEventDetail{},
+		ExpiredProposals: []string{},
+	}
+	releasedIDs := []string{}
+	for _, proposal := range proposals {
+		status, err := s.releaseProposal(ctx, *proposal, &eventDetail)
+		if err != nil {
+			return nil, err
+		}
+		if status == Approved {
+			releasedIDs = append(releasedIDs, proposal.ID)
+		}
+	}
+	if err := s.setReleasedEvent(ctx, eventDetail); err != nil {
+		return nil, err
+	}
+	return releasedIDs, nil
+}
+
+// -- Internal logics
+
+// validateDeploymentWindow validates the not-before and not-after times of the deployment window.
+func validateDeploymentWindow(notBefore string, notAfter string) error {
+	var notBeforeTime, notAfterTime time.Time
+	var err error
+	if notBefore != "" {
+		if notBeforeTime, err = time.Parse(time.RFC3339, notBefore); err != nil {
+			return fmt.Errorf("the parameter 'NotBefore' should be RFC3339 format: %v", err)
+		}
+	}
+	if notAfter != "" {
+		if notAfterTime, err = time.Parse(time.RFC3339, notAfter); err != nil {
+			return fmt.Errorf("the parameter 'NotAfter' should be RFC3339 format: %v", err)
+		}
+	}
+	if notBefore != "" && notAfter != "" && !notAfterTime.After(notBeforeTime) {
+		return fmt.Errorf("the parameter 'NotAfter' should be later than 'NotBefore'")
+	}
+	return nil
+}
+
+// updateStatusOnApproval changes the status of the approved proposal.
+// If the proposal depends on the proposals which have not been committed yet,
+// this holds the proposal as waiting until ReleaseDependentProposals releases it.
+// If any of the dependencies can no longer be committed, this changes the status to rejected.
+// Otherwise, this changes the status to approved if its deployment window is open,
+// or holds the proposal as scheduled until ReleaseScheduledProposals releases it.
+func (s *SmartContract) updateStatusOnApproval(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	committed, err := s.areDependenciesCommitted(ctx, proposal, nil)
+	if err == errDependencyNotCommittable {
+		return s.updateStatusToRejected(ctx, proposal)
+	}
+	if err != nil {
+		return err
+	}
+	if !committed {
+		return s.updateStatusToWaiting(ctx, proposal)
+	}
+	open, err := s.isDeploymentWindowOpen(ctx, proposal)
+	if err != nil {
+		return err
+	}
+	if open {
+		return s.updateStatusToApproved(ctx, proposal)
+	}
+	return s.updateStatusToScheduled(ctx, proposal)
+}
+
+// releaseProposal changes the status of the held proposal to approved if its deployment window is open,
+// to expired if the window has closed, or otherwise to scheduled, and records the result in the event detail.
+// This returns the status of the proposal after the change.
+func (s *SmartContract) releaseProposal(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal, eventDetail *ReleasedEventDetail) (string, error) {
+	closed, err := s.isDeploymentWindowClosed(ctx, proposal)
+	if err != nil {
+		return "", err
+	}
+	if closed {
+		proposal.Status = Expired
+		if err := s.putProposal(ctx, &proposal); err != nil {
+			return "", fmt.Errorf("failed to update the status: %v", err)
+		}
+		eventDetail.ExpiredProposals = append(eventDetail.ExpiredProposals, proposal.ID)
+		return Expired, nil
+	}
+
+	open, err := s.isDeploymentWindowOpen(ctx, proposal)
+	if err != nil {
+		return "", err
+	}
+	if !open {
+		if proposal.Status == Scheduled {
+			return Scheduled, nil
+		}
+		proposal.Status = Scheduled
+		if err := s.putProposal(ctx, &proposal); err != nil {
+			return "", fmt.Errorf("failed to update the status: %v", err)
+		}
+		eventDetail.ScheduledProposals = append(eventDetail.ScheduledProposals, proposal.ID)
+		return Scheduled, nil
+	}
+
+	deployment, err := s.approveProposal(ctx, proposal)
+	if err != nil {
+		return "", err
+	}
+	eventDetail.Deployments = append(eventDetail.Deployments, *deployment)
+	return Approved, nil
+}
+
+// setReleasedEvent emits ReleasedEvent unless no proposal is released, expired, scheduled or rejected.
+func (s *SmartContract) setReleasedEvent(ctx contractapi.TransactionContextInterface, eventDetail ReleasedEventDetail) error {
+	if len(eventDetail.Deployments) == 0 && len(eventDetail.ExpiredProposals) == 0 && len(eventDetail.ScheduledProposals) == 0 && len(eventDetail.RejectedProposals) == 0 {
+		return nil
+	}
+	return s.setEvent(ctx, ReleasedEvent, "", eventDetail)
+}
+
+func (s *SmartContract) updateStatusToScheduled(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) error {
+	proposal.Status = Scheduled
+
+	// Put proposal to stateDB
+	if err := s.putProposal(ctx, &proposal); err != nil {
+		return err
+	}
+
+	// -- Set Event
+	return s.setEvent(ctx, ScheduledEvent, proposal.ID, proposal)
+}
+
+// isDeploymentWindowOpen returns whether the current transaction is in the deployment window of the proposal.
+func (s *SmartContract) isDeploymentWindowOpen(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) (bool, error) {
+	if proposal.NotBefore == "" && proposal.NotAfter == "" {
+		return true, nil
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+	if proposal.NotBefore != "" && !isOverdue(proposal.NotBefore, txTimestamp) {
+		return false, nil
+	}
+	return proposal.NotAfter == "" || !isOverdue(proposal.NotAfter, txTimestamp), nil
+}
+
+// isDeploymentWindowClosed returns whether the deployment window of the proposal has already closed.
+func (s *SmartContract) isDeploymentWindowClosed(ctx contractapi.TransactionContextInterface, proposal ChaincodeUpdateProposal) (bool, error) {
+	if proposal.NotAfter == "" {
+		return false, nil
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+	return isOverdue(proposal.NotAfter, txTimestamp), nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestScheduledDeployment(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	now := time.Now().Truncate(time.Second)
+	setNow := func(ts time.Time) {
+		chaincodeStub.GetTxTimestampReturns(timestamppb.New(ts), nil)
+	}
+	setNow(now)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	requestProposal := func(proposalID string, notBefore time.Duration, notAfter time.Duration) error {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = proposalID
+		if notBefore != 0 {
+			input.NotBefore = now.Add(notBefore).Format(time.RFC3339)
+		}
+		if notAfter != 0 {
+			input.NotAfter = now.Add(notAfter).Format(time.RFC3339)
+		}
+		_, err := sc.RequestProposal(transactionContext, input)
+		return err
+	}
+	vote := func(proposalID string) {
+		chaincodeStub.GetCreatorReturns(org2MSP, nil)
+		require.NoError(t, sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID}))
+	}
+	requireStatus := func(proposalID string, status string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, proposal.Status)
+	}
+
+	// Case: The proposal approved before the deployment window is scheduled
+	require.NoError(t, requestProposal("request-1", time.Hour, 3*time.Hour))
+	vote("request-1")
+	requireStatus("request-1", Scheduled)
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "scheduledEvent.request-1", eventName)
+	var scheduledProposal ChaincodeUpdateProposal
+	require.NoError(t, json.Unmarshal(eventPayload, &scheduledProposal))
+	require.Equal(t, now.Add(time.Hour).Format(time.RFC3339), scheduledProposal.NotBefore)
+	require.Equal(t, now.Add(3*time.Hour).Format(time.RFC3339), scheduledProposal.NotAfter)
+
+	// Case: The proposal approved in the deployment window is approved immediately
+	require.NoError(t, requestProposal("request-2", -time.Hour, time.Hour))
+	vote("request-2")
+	requireStatus("request-2", Approved)
+	eventName, _ = lastEvent(chaincodeStub)
+	require.Equal(t, "prepareToDeployEvent.request-2", eventName)
+
+	// Case: Nothing is released before the deployment window
+	require.NoError(t, requestProposal("request-3", time.Hour, 90*time.Minute))
+	vote("request-3")
+	setEventCallCount := chaincodeStub.SetEventCallCount()
+	releasedIDs, err := sc.ReleaseScheduledProposals(transactionContext)
+	require.NoError(t, err)
+	require.Empty(t, releasedIDs)
+	require.Equal(t, setEventCallCount, chaincodeStub.SetEventCallCount())
+
+	// Case: The scheduled proposal blocks another proposal for the same chaincode
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	input.ID = "request-1-another"
+	input.ChaincodeName = "request-1"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "the chaincode already has an open proposal (request-1)")
+
+	// Case: The proposals are released in the deployment window, and the proposal whose window has closed is expired
+	setNow(now.Add(2 * time.Hour))
+	releasedIDs, err = sc.ReleaseScheduledProposals(transactionContext)
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1"}, releasedIDs)
+	requireStatus("request-1", Approved)
+	requireStatus("request-3", Expired)
+
+	eventName, eventPayload = lastEvent(chaincodeStub)
+	require.Equal(t, "releasedEvent", eventName)
+	var eventDetail ReleasedEventDetail
+	require.NoError(t, json.Unmarshal(eventPayload, &eventDetail))
+	require.Len(t, eventDetail.Deployments, 1)
+	require.Equal(t, "request-1", eventDetail.Deployments[0].Proposal.ID)
+	require.Equal(t, Approved, eventDetail.Deployments[0].Proposal.Status)
+	require.Equal(t, []string{"Org1MSP", "Org2MSP"}, eventDetail.Deployments[0].OperationTargets)
+	require.Equal(t, []string{"request-3"}, eventDetail.ExpiredProposals)
+
+	// Case: The released proposal follows the same flow as the other approved proposals
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"}))
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	require.NoError(t, sc.Acknowledge(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1"}))
+	requireStatus("request-1", Acknowledged)
+
+	// Case: The proposer can withdraw the scheduled proposal
+	setNow(now)
+	require.NoError(t, requestProposal("request-4", time.Hour, 0))
+	vote("request-4")
+	requireStatus("request-4", Scheduled)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	require.NoError(t, sc.WithdrawProposal(transactionContext, "request-4"))
+	requireStatus("request-4", Withdrawn)
+
+	// Case: Fail to request the proposal with the invalid deployment window
+	require.EqualError(t, requestProposal("request-5", 2*time.Hour, time.Hour), "the parameter 'NotAfter' should be later than 'NotBefore'")
+	require.EqualError(t, requestProposal("request-5", 0, -time.Hour), "the parameter 'NotAfter' should be later than the current time")
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input = baseProposalAndInput("")
+	input.ID = "request-5"
+	input.ChaincodeName = "request-5"
+	input.NotBefore = "tomorrow"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, `the parameter 'NotBefore' should be RFC3339 format: parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"`)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SupersededVote describes a vote which was changed or retracted, and which is stored as an audit record in the ledger.
+type SupersededVote struct {
+	ObjectType string  `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	Vote       History `json:"vote"`    // the superseded vote
+	Action     string  `json:"action"`  // VoteChanged or VoteRetracted
+	TxID       string  `json:"txID"`    // the transaction which superseded the vote
+	Time       string  `json:"time"`
+}
+
+// Object types
+const (
+	SupersededVoteObjectType = "supersededVote"
+)
+
+// Actions which supersede votes
+const (
+	VoteChanged   = "changed"
+	VoteRetracted = "retracted"
+)
+
+// ChangeVote changes the vote of the organization for the chaincode update proposal.
+// This function is only available before the decision of the proposal.
+// The superseded vote is kept as an audit record in the ledger.
+// Also, like Vote, if the proposal meets the criteria after the change, this changes the status of the proposal.
+//
+// Arguments:
+//   0: taskStatusUpdateRequest - the new vote for the chaincode update proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   the same as Vote
+//
+func (s *SmartContract) ChangeVote(ctx contractapi.TransactionContextInterface, taskStatusUpdateRequest TaskStatusUpdateRequest) error {
+
+	if err := s.authorize(ctx, "ChangeVote"); err != nil {
+		return err
+	}
+
+	// Validate input
+	if taskStatusUpdateRequest.ProposalID == "" {
+		return fmt.Errorf("the required parameter 'ProposalID' is empty")
+	}
+
+	if taskStatusUpdateRequest.Status != Agreed && taskStatusUpdateRequest.Status != Disagreed {
+		return fmt.Errorf("task status for vote should be %s or %s", Agreed, Disagreed)
+	}
+
+	if taskStatusUpdateRequest.OnBehalfOf != "" {
+		return ErrDelegatedVoteNotAvailable
+	}
+
+	proposal, currentVote, err := s.getProposalAndVoteInVoting(ctx, taskStatusUpdateRequest.ProposalID)
+	if err != nil {
+		return err
+	}
+	if currentVote.Status == taskStatusUpdateRequest.Status {
+		return fmt.Errorf("the vote is already %s", currentVote.Status)
+	}
+
+	// Keep the current vote as an audit record and overwrite it
+	if err := s.putSupersededVote(ctx, *currentVote, VoteChanged); err != nil {
+		return fmt.Errorf("failed to put the superseded vote: %v", err)
+	}
+	history, err := s.putHistory(ctx, taskStatusUpdateRequest.ProposalID, Vote, taskStatusUpdateRequest.Status, taskStatusUpdateRequest.Data, true)
+	if err != nil {
+		return fmt.Errorf("failed to put the history: %v", err)
+	}
+
+	return s.evaluateVotes(ctx, *proposal, *history)
+}
+
+// RetractVote retracts the vote of the organization for the chaincode update proposal.
+// This function is only available before the decision of the proposal, and the proposer cannot retract the vote.
+// The retracted vote is kept as an audit record in the ledger.
+// Since the retraction only decreases the votes, it never changes the status of the proposal.
+//
+// Arguments:
+//   0: proposalID - the ID for the chaincode update proposal
+//
+// Returns:
+//   0: error
+//
+// Events:
+//   name: NewVoteEvent(<proposalID>)
+//   payload: nil
+//
+func (s *SmartContract) RetractVote(ctx contractapi.TransactionContextInterface, proposalID string) error {
+
+	if err := s.authorize(ctx, "RetractVote"); err != nil {
+		return err
+	}
+
+	// Validate input
+	if proposalID == "" {
+		return fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	proposal, currentVote, err := s.getProposalAndVoteInVoting(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if proposal.Creator == currentVote.OrgID {
+		return fmt.Errorf("the proposer cannot retract the vote (withdraw the proposal instead)")
+	}
+
+	// Keep the current vote as an audit record and delete it
+	if err := s.putSupersededVote(ctx, *currentVote, VoteRetracted); err != nil {
+		return fmt.Errorf("failed to put the superseded vote: %v", err)
+	}
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(HistoryObjectType, []string{proposalID, Vote, currentVote.OrgID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for history: %v", err)
+	}
+	if err := ctx.GetStub().DelState(compositeKey); err != nil {
+		return fmt.Errorf("error happened deleting the history: %v", err)
+	}
+
+	return s.setEvent(ctx, NewVoteEvent, proposalID, nil)
+}
+
+// GetSupersededVotes returns the votes which were changed or retracted for the chaincode update proposal.
+//
+// Arguments:
+//   0: proposalID - the ID for the chaincode update proposal
+//
+// Returns:
+//   0: the superseded votes (ordered by the org ID and the transaction ID)
+//   1: error
+//
+func (s *SmartContract) GetSupersededVotes(ctx contractapi.TransactionContextInterface, proposalID string) ([]*SupersededVote, error) {
+	if proposalID == "" {
+		return nil, fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(SupersededVoteObjectType, []string{proposalID})
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading keys from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	supersededVotes := []*SupersededVote{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over available superseded votes: %v", err)
+		}
+		var supersededVote SupersededVote
+		if err = json.Unmarshal(result.Value, &supersededVote); err != nil {
+			return nil, fmt.Errorf("error happened unmarshalling a superseded vote JSON representation to struct: %v", err)
+		}
+		supersededVotes = append(supersededVotes, &supersededVote)
+	}
+	return supersededVotes, nil
+}
+
+// getProposalAndVoteInVoting returns the proposal in voting and the current vote of the organization for it.
+func (s *SmartContract) getProposalAndVoteInVoting(ctx contractapi.TransactionContextInterface, proposalID string) (*ChaincodeUpdateProposal, *History, error) {
+	// Get proposal from StateDB
+	proposal, err := s.GetProposal(ctx, proposalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the proposal: %v", err)
+	}
+	// If the proposal is expired (or its voting deadline has passed), return error
+	expired, err := s.isExpired(ctx, *proposal)
+	if err != nil {
+		return nil, nil, err
+	}
+	if expired {
+		return nil, nil, ErrProposalExpired
+	}
+	// If the proposal status already got changed from "Proposed", return error
+	if proposal.Status != Proposed {
+		return nil, nil, fmt.Errorf("the voting is already closed")
+	}
+	// If the proposal is a member of a release, the votes should be made for the release
+	if proposal.ReleaseID != "" {
+		return nil, nil, ErrReleaseMember
+	}
+
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(HistoryObjectType, []string{proposalID, Vote, mspID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error happened creating composite key for history: %v", err)
+	}
+	historyJSON, err := ctx.GetStub().GetState(compositeKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if historyJSON == nil {
+		return nil, nil, fmt.Errorf("%s has not voted for the proposal", mspID)
+	}
+	var history History
+	if err = json.Unmarshal(historyJSON, &history); err != nil {
+		return nil, nil, fmt.Errorf("error happened unmarshalling a history JSON representation to struct: %v", err)
+	}
+	return proposal, &history, nil
+}
+
+// putSupersededVote puts the vote superseded by the current transaction as an audit record.
+func (s *SmartContract) putSupersededVote(ctx contractapi.TransactionContextInterface, vote History, action string) error {
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+	supersededVote := SupersededVote{
+		ObjectType: SupersededVoteObjectType,
+		Vote:       vote,
+		Action:     action,
+		TxID:       ctx.GetStub().GetTxID(),
+		Time:       txTimestamp,
+	}
+	supersededVoteJSON, err := json.Marshal(supersededVote)
+	if err != nil {
+		return err
+	}
+
+	compositeKey, err := ctx.GetStub().CreateCompositeKey(SupersededVoteObjectType, []string{vote.ProposalID, vote.OrgID, supersededVote.TxID})
+	if err != nil {
+		return fmt.Errorf("error happened creating composite key for superseded vote: %v", err)
+	}
+	if err = ctx.GetStub().PutState(compositeKey, supersededVoteJSON); err != nil {
+		return fmt.Errorf("error happened putting the superseded vote: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeVoteAndRetractVote(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincodeWithThreeOrgs
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// The proposal is approved by 2 of 3 orgs and rejected by 2 of 3 orgs
+	requestProposal := func(proposalID string) {
+		chaincodeStub.GetCreatorReturns(org1MSP, nil)
+		_, input := baseProposalAndInput("")
+		input.ID = proposalID
+		input.ChaincodeName = proposalID
+		_, err := sc.RequestProposal(transactionContext, input)
+		require.NoError(t, err)
+	}
+	vote := func(creator []byte, proposalID string, status string) {
+		chaincodeStub.GetCreatorReturns(creator, nil)
+		err := sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: proposalID, Status: status})
+		require.NoError(t, err)
+	}
+	requireStatus := func(proposalID string, status string) {
+		proposal, err := sc.GetProposal(transactionContext, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, status, proposal.Status)
+	}
+	requireVotes := func(proposalID string, expected map[string]string) {
+		histories, err := sc.GetHistories(transactionContext, HistoryQueryParams{ProposalID: proposalID, TaskID: Vote})
+		require.NoError(t, err)
+		actual := map[string]string{}
+		for _, history := range histories {
+			actual[history.OrgID] = history.Status
+		}
+		require.Equal(t, expected, actual)
+	}
+
+	// Case: The changed vote approves the proposal
+	requestProposal("request-1")
+	vote(org2MSP, "request-1", Disagreed)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	chaincodeStub.GetTxIDReturns("tx-1")
+	err := sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Agreed})
+	require.NoError(t, err)
+	requireStatus("request-1", Approved)
+	requireVotes("request-1", map[string]string{"Org1MSP": Agreed, "Org2MSP": Agreed})
+
+	supersededVotes, err := sc.GetSupersededVotes(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Len(t, supersededVotes, 1)
+	require.Equal(t, SupersededVoteObjectType, supersededVotes[0].ObjectType)
+	require.Equal(t, VoteChanged, supersededVotes[0].Action)
+	require.Equal(t, "tx-1", supersededVotes[0].TxID)
+	require.Equal(t, "Org2MSP", supersededVotes[0].Vote.OrgID)
+	require.Equal(t, Disagreed, supersededVotes[0].Vote.Status)
+
+	// Case: Fail to change the vote after the decision
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Disagreed})
+	require.EqualError(t, err, "the voting is already closed")
+
+	// Case: The retracted vote is removed from the votes and the org can vote again
+	requestProposal("request-2")
+	vote(org2MSP, "request-2", Disagreed)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	chaincodeStub.GetTxIDReturns("tx-2")
+	err = sc.RetractVote(transactionContext, "request-2")
+	require.NoError(t, err)
+	requireStatus("request-2", Proposed)
+	requireVotes("request-2", map[string]string{"Org1MSP": Agreed})
+	eventName, eventPayload := lastEvent(chaincodeStub)
+	require.Equal(t, "newVoteEvent.request-2", eventName)
+	require.Nil(t, eventPayload)
+
+	supersededVotes, err = sc.GetSupersededVotes(transactionContext, "request-2")
+	require.NoError(t, err)
+	require.Len(t, supersededVotes, 1)
+	require.Equal(t, VoteRetracted, supersededVotes[0].Action)
+	require.Equal(t, "tx-2", supersededVotes[0].TxID)
+	require.Equal(t, Disagreed, supersededVotes[0].Vote.Status)
+
+	vote(org2MSP, "request-2", Agreed)
+	requireStatus("request-2", Approved)
+
+	// Case: The changed vote of the proposer rejects the proposal
+	requestProposal("request-3")
+	vote(org2MSP, "request-3", Disagreed)
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-3", Status: Disagreed})
+	require.NoError(t, err)
+	requireStatus("request-3", Rejected)
+
+	// Case: Fail to change or retract the vote with invalid requests
+	requestProposal("request-4")
+	chaincodeStub.GetCreatorReturns(org3MSP, nil)
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-4", Status: Disagreed})
+	require.EqualError(t, err, "Org3MSP has not voted for the proposal")
+	err = sc.RetractVote(transactionContext, "request-4")
+	require.EqualError(t, err, "Org3MSP has not voted for the proposal")
+
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-4", Status: Agreed})
+	require.EqualError(t, err, "the vote is already agreed")
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-4", Status: "unknown"})
+	require.EqualError(t, err, "task status for vote should be agreed or disagreed")
+	err = sc.ChangeVote(transactionContext, TaskStatusUpdateRequest{Status: Agreed})
+	require.EqualError(t, err, "the required parameter 'ProposalID' is empty")
+	err = sc.RetractVote(transactionContext, "request-4")
+	require.EqualError(t, err, "the proposer cannot retract the vote (withdraw the proposal instead)")
+	err = sc.RetractVote(transactionContext, "")
+	require.EqualError(t, err, "the required parameter 'proposalID' is empty")
+}
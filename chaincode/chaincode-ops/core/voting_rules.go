@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric/common/util"
+)
+
+// Voting rules in the voting config for chaincode update proposals:
+// - RequiredOrgs: the proposal is approved only if all of the required orgs in the channel agree,
+//   and it is rejected as soon as one of them disagrees (i.e., the required orgs have veto power).
+// - Threshold and OrgWeights: the proposal is approved if the total weight of the agreed orgs reaches
+//   the threshold (the fraction of the total weight of the orgs in the channel),
+//   and it is rejected if the threshold becomes unachievable by the disagreed orgs.
+//   If Threshold is not set, the number of votes decided by MaxMaliciousOrgs is used instead (OrgWeights is ignored).
+
+// hasVotingRules returns whether the voting config has the voting rules other than MaxMaliciousOrgs.
+func (c VotingConfig) hasVotingRules() bool {
+	return c.Threshold > 0 || len(c.RequiredOrgs) > 0
+}
+
+// weight returns the weight of the org in votes.
+func (c VotingConfig) weight(mspID string) int {
+	if weight, ok := c.OrgWeights[mspID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// validateVotingConfig validates the voting config.
+func validateVotingConfig(votingConfig VotingConfig) error {
+	if votingConfig.MaxMaliciousOrgs < 0 {
+		return fmt.Errorf("number of max malicious orgs in votes should be greater than 0")
+	}
+	if votingConfig.Threshold < 0 || votingConfig.Threshold > 1 {
+		return fmt.Errorf("the threshold should be between 0 and 1")
+	}
+	for mspID, weight := range votingConfig.OrgWeights {
+		if weight < 1 {
+			return fmt.Errorf("the weight of %s should be greater than or equal to 1", mspID)
+		}
+	}
+	for _, mspID := range votingConfig.RequiredOrgs {
+		if mspID == "" {
+			return fmt.Errorf("the required orgs should not contain an empty MSP ID")
+		}
+	}
+	switch votingConfig.AcknowledgeCriteria {
+	case "", AckByAll, AckByMajority, AckByAgreedVoters:
+	case AckByCount:
+		if votingConfig.AcknowledgeCount < 1 {
+			return fmt.Errorf("number of orgs for acknowledging the deployment should be greater than 0")
+		}
+	default:
+		return fmt.Errorf("incorrect acknowledge criteria - expecting %s, %s, %s or %s", AckByAll, AckByMajority, AckByCount, AckByAgreedVoters)
+	}
+	return nil
+}
+
+// meetVotingRules checks whether the votes (orgs) meet the voting rules in the voting config.
+// meetCount is the result of the check with the number of votes, which is used if the threshold is not set.
+func (s *SmartContract) meetVotingRules(ctx contractapi.TransactionContextInterface, votingConfig VotingConfig, orgs map[string]string, meetCount bool, checkUnachievable bool, targetChannel string) (bool, error) {
+	channelOrgs, err := s.getOrganizationsInChannel(ctx, targetChannel)
+	if err != nil {
+		return false, err
+	}
+	inChannel := map[string]bool{}
+	for _, mspID := range channelOrgs {
+		inChannel[mspID] = true
+	}
+
+	// Check the required orgs (the required orgs which are not in the channel are ignored)
+	for _, mspID := range votingConfig.RequiredOrgs {
+		if !inChannel[mspID] {
+			continue
+		}
+		_, voted := orgs[mspID]
+		if checkUnachievable && voted {
+			return true, nil
+		}
+		if !checkUnachievable && !voted {
+			return false, nil
+		}
+	}
+
+	if votingConfig.Threshold == 0 {
+		return meetCount, nil
+	}
+
+	// Check the threshold with the weights
+	totalWeight, votedWeight := 0, 0
+	for _, mspID := range channelOrgs {
+		totalWeight += votingConfig.weight(mspID)
+		if _, ok := orgs[mspID]; ok {
+			votedWeight += votingConfig.weight(mspID)
+		}
+	}
+	if totalWeight == 0 {
+		// No org in the channel can approve the proposal (e.g., by the weights set before validating them)
+		return checkUnachievable, nil
+	}
+	thresholdWeight := votingConfig.Threshold * float64(totalWeight)
+	if checkUnachievable {
+		return float64(totalWeight-votedWeight) < thresholdWeight, nil
+	}
+	return float64(votedWeight) >= thresholdWeight, nil
+}
+
+// getOrganizationsInChannel returns the MSP IDs of the organizations in the channel from channel-ops.
+func (s *SmartContract) getOrganizationsInChannel(ctx contractapi.TransactionContextInterface, channelID string) ([]string, error) {
+	channelOpsArgs := util.ToChaincodeArgs("GetOrganizationsInChannel", channelID)
+	response := ctx.GetStub().InvokeChaincode(channelOpsCCName(), channelOpsArgs, "")
+	if response.Status != shim.OK {
+		return nil, fmt.Errorf("failed to call get organizations in channel (code: %d, message: %v)",
+			response.Status, response.Message)
+	}
+	orgs := []string{}
+	if err := json.Unmarshal(response.Payload, &orgs); err != nil {
+		return nil, fmt.Errorf("failed to call get organizations in channel: %v", err)
+	}
+	return orgs, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/core/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetVotingRules(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+
+	sc := &SmartContract{}
+
+	// The orgs in the channel are Org1MSP and Org2MSP (the total weight is 4)
+	weighted := VotingConfig{OrgWeights: map[string]int{"Org1MSP": 3}, Threshold: 0.75}
+	required := VotingConfig{RequiredOrgs: []string{"Org2MSP", "Org9MSP"}}
+	// The voting config with no weight, which is rejected by validateVotingConfig
+	weightless := VotingConfig{OrgWeights: map[string]int{"Org1MSP": 0, "Org2MSP": 0}, Threshold: 0.5}
+
+	tests := []struct {
+		name              string
+		votingConfig      VotingConfig
+		orgs              map[string]string
+		meetCount         bool
+		checkUnachievable bool
+		expected          bool
+	}{
+		{"approved by the weight of the heavy org", weighted, map[string]string{"Org1MSP": Agreed}, false, false, true},
+		{"not approved by the weight of the light org", weighted, map[string]string{"Org2MSP": Agreed}, true, false, false},
+		{"unachievable by the disagreement of the heavy org", weighted, map[string]string{"Org1MSP": Disagreed}, false, true, true},
+		{"achievable after the disagreement of the light org", weighted, map[string]string{"Org2MSP": Disagreed}, true, true, false},
+		{"not approved without the required org", required, map[string]string{"Org1MSP": Agreed}, true, false, false},
+		{"approved with the required org and the count", required, map[string]string{"Org2MSP": Agreed}, true, false, true},
+		{"not approved with the required org but without the count", required, map[string]string{"Org2MSP": Agreed}, false, false, false},
+		{"unachievable by the veto of the required org", required, map[string]string{"Org2MSP": Disagreed}, false, true, true},
+		{"achievable after the disagreement of the other org", required, map[string]string{"Org1MSP": Disagreed}, false, true, false},
+		{"not approved without any weight", weightless, map[string]string{"Org1MSP": Agreed}, true, false, false},
+		{"unachievable without any weight", weightless, map[string]string{}, false, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := sc.meetVotingRules(transactionContext, tt.votingConfig, tt.orgs, tt.meetCount, tt.checkUnachievable, "mychannel")
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestVoteWithRequiredOrgs(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.InvokeChaincodeStub = invokeChaincode
+	chaincodeStub.GetChannelIDReturns("ops-channel")
+	chaincodeStub.GetTxTimestampReturns(ptypes.TimestampNow(), nil)
+	newWorldState(chaincodeStub)
+
+	sc := &SmartContract{}
+
+	// Prepare the voting config which requires 1 vote including the vote of Org2MSP
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, err := sc.RequestGovernanceProposal(transactionContext, GovernanceProposalInput{
+		ID:           "governance-1",
+		VotingConfig: VotingConfig{MaxMaliciousOrgs: 0, RequiredOrgs: []string{"Org2MSP"}},
+	})
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.VoteForGovernanceProposal(transactionContext, TaskStatusUpdateRequest{ProposalID: "governance-1"})
+	require.NoError(t, err)
+
+	// Case: The proposal is not approved without the vote of the required org
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input := baseProposalAndInput("")
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Proposed, proposal.Status)
+
+	// Case: The proposal is approved by the vote of the required org
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-1", Status: Agreed})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, proposal.Status)
+
+	// Case: The proposal is rejected by the veto of the required org
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	_, input = baseProposalAndInput("")
+	input.ID = "request-2"
+	input.ChaincodeName = "basic2"
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.NoError(t, err)
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	err = sc.Vote(transactionContext, TaskStatusUpdateRequest{ProposalID: "request-2", Status: Disagreed})
+	require.NoError(t, err)
+	proposal, err = sc.GetProposal(transactionContext, "request-2")
+	require.NoError(t, err)
+	require.Equal(t, Rejected, proposal.Status)
+}
+
+func TestValidateVotingConfig(t *testing.T) {
+	require.NoError(t, validateVotingConfig(VotingConfig{OrgWeights: map[string]int{"Org1MSP": 2}, RequiredOrgs: []string{"Org1MSP"}, Threshold: 0.5}))
+	require.EqualError(t, validateVotingConfig(VotingConfig{MaxMaliciousOrgs: -1}), "number of max malicious orgs in votes should be greater than 0")
+	require.EqualError(t, validateVotingConfig(VotingConfig{Threshold: 1.5}), "the threshold should be between 0 and 1")
+	require.EqualError(t, validateVotingConfig(VotingConfig{OrgWeights: map[string]int{"Org1MSP": -1}}), "the weight of Org1MSP should be greater than or equal to 1")
+	require.EqualError(t, validateVotingConfig(VotingConfig{OrgWeights: map[string]int{"Org1MSP": 0}}), "the weight of Org1MSP should be greater than or equal to 1")
+	require.EqualError(t, validateVotingConfig(VotingConfig{RequiredOrgs: []string{""}}), "the required orgs should not contain an empty MSP ID")
+	require.NoError(t, validateVotingConfig(VotingConfig{AcknowledgeCriteria: AckByCount, AcknowledgeCount: 2}))
+	require.EqualError(t, validateVotingConfig(VotingConfig{AcknowledgeCriteria: AckByCount}), "number of orgs for acknowledging the deployment should be greater than 0")
+	require.EqualError(t, validateVotingConfig(VotingConfig{AcknowledgeCriteria: "any"}), "incorrect acknowledge criteria - expecting all, majority, count or agreedVoters")
+}
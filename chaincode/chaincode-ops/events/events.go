@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package events defines the versioned envelope of the chaincode events emitted by the OpsSC chaincodes
+// (chaincode-ops and channel-ops), and decodes it for the consumers of the events.
+//
+// Every event is named "<type>.<proposalID>" (or "<type>" for the events which are not bound to a proposal),
+// and its payload is the JSON representation of Envelope, which carries the event-specific data in Data.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SpecVersion is the version of the envelope schema emitted by the chaincodes.
+// The minor version is incremented for backward compatible changes (e.g., adding a field),
+// and the major version is incremented for incompatible ones.
+const SpecVersion = "1.0"
+
+// Sources of the events
+const (
+	ChaincodeOpsSource = "chaincode-ops"
+	ChannelOpsSource   = "channel-ops"
+)
+
+var (
+	// ErrUnsupportedSpecVersion is returned when decoding the envelope whose major version is not supported.
+	ErrUnsupportedSpecVersion = fmt.Errorf("unsupported spec version")
+	// ErrNoData is returned when decoding the data of the event which has no data.
+	ErrNoData = fmt.Errorf("the event has no data")
+)
+
+// Envelope is the common structure of the payloads of the chaincode events.
+type Envelope struct {
+	SpecVersion string          `json:"specVersion"`          // the version of the envelope schema (SpecVersion)
+	Type        string          `json:"type"`                 // the event type (e.g., prepareToDeployEvent)
+	Source      string          `json:"source"`               // the chaincode which emits the event (ChaincodeOpsSource or ChannelOpsSource)
+	ProposalID  string          `json:"proposalID,omitempty"` // the ID of the proposal (empty for the events which are not bound to a proposal)
+	ChannelID   string          `json:"channelID"`            // the channel in which the event is emitted
+	Actor       string          `json:"actor"`                // the MSP ID of the creator of the transaction
+	TxID        string          `json:"txID"`                 // the ID of the transaction
+	Time        string          `json:"time"`                 // the timestamp of the transaction (RFC3339)
+	Data        json.RawMessage `json:"data,omitempty"`       // the event-specific data (omitted for the events without data)
+}
+
+// Name returns the name of the chaincode event carrying the envelope.
+func (e *Envelope) Name() string {
+	if e.ProposalID == "" {
+		return e.Type
+	}
+	return fmt.Sprintf("%s.%s", e.Type, e.ProposalID)
+}
+
+// SetData sets the JSON representation of the given data to the envelope.
+// If the data is nil, the envelope has no data.
+func (e *Envelope) SetData(data interface{}) error {
+	if data == nil {
+		e.Data = nil
+		return nil
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	e.Data = dataJSON
+	return nil
+}
+
+// DecodeData decodes the data of the envelope into the given value.
+func (e *Envelope) DecodeData(v interface{}) error {
+	if len(e.Data) == 0 {
+		return ErrNoData
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("error happened unmarshalling the event data: %v", err)
+	}
+	return nil
+}
+
+// Decode decodes the payload of a chaincode event into the envelope.
+// This fails if the major version of the envelope differs from the one of SpecVersion.
+func Decode(payload []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("error happened unmarshalling the event envelope: %v", err)
+	}
+	if majorVersion(envelope.SpecVersion) != majorVersion(SpecVersion) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedSpecVersion, envelope.SpecVersion)
+	}
+	if envelope.Type == "" {
+		return nil, fmt.Errorf("the event envelope has no type")
+	}
+	return &envelope, nil
+}
+
+// ParseName splits the name of a chaincode event into the event type and the proposal ID.
+func ParseName(name string) (eventType string, proposalID string) {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func majorVersion(version string) string {
+	return strings.SplitN(version, ".", 2)[0]
+}
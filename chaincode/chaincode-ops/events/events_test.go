@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	envelope := Envelope{
+		SpecVersion: SpecVersion,
+		Type:        "prepareToDeployEvent",
+		Source:      ChaincodeOpsSource,
+		ProposalID:  "request-1",
+		ChannelID:   "ops-channel",
+		Actor:       "Org1MSP",
+		TxID:        "tx-1",
+		Time:        "2026-01-01T00:00:00Z",
+	}
+	require.Equal(t, "prepareToDeployEvent.request-1", envelope.Name())
+	require.NoError(t, envelope.SetData(map[string]string{"key": "value"}))
+
+	payload, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	// Case: Decode the envelope and its data
+	decoded, err := Decode(payload)
+	require.NoError(t, err)
+	require.Equal(t, "prepareToDeployEvent", decoded.Type)
+	require.Equal(t, "request-1", decoded.ProposalID)
+	require.Equal(t, "Org1MSP", decoded.Actor)
+	var data map[string]string
+	require.NoError(t, decoded.DecodeData(&data))
+	require.Equal(t, map[string]string{"key": "value"}, data)
+
+	// Case: The event which is not bound to a proposal is named only with the type
+	envelope.ProposalID = ""
+	require.Equal(t, "prepareToDeployEvent", envelope.Name())
+
+	// Case: The envelope without data
+	require.NoError(t, envelope.SetData(nil))
+	payload, err = json.Marshal(envelope)
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), `"data"`)
+	decoded, err = Decode(payload)
+	require.NoError(t, err)
+	require.ErrorIs(t, decoded.DecodeData(&data), ErrNoData)
+}
+
+func TestDecodeWithInvalidPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		errMsg  string
+	}{
+		{
+			name:    "not JSON",
+			payload: "request-1",
+			errMsg:  "error happened unmarshalling the event envelope: invalid character 'r' looking for beginning of value",
+		},
+		{
+			name:    "unsupported major version",
+			payload: `{"specVersion":"2.0","type":"newProposalEvent"}`,
+			errMsg:  `unsupported spec version: "2.0"`,
+		},
+		{
+			name:    "no spec version",
+			payload: `{"type":"newProposalEvent"}`,
+			errMsg:  `unsupported spec version: ""`,
+		},
+		{
+			name:    "no type",
+			payload: `{"specVersion":"1.0"}`,
+			errMsg:  "the event envelope has no type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.payload))
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+
+	// Case: The newer minor version is accepted
+	envelope, err := Decode([]byte(`{"specVersion":"1.1","type":"newProposalEvent","extra":"ignored"}`))
+	require.NoError(t, err)
+	require.Equal(t, "newProposalEvent", envelope.Type)
+}
+
+func TestParseName(t *testing.T) {
+	eventType, proposalID := ParseName("prepareToDeployEvent.request-1")
+	require.Equal(t, "prepareToDeployEvent", eventType)
+	require.Equal(t, "request-1", proposalID)
+
+	eventType, proposalID = ParseName("deployEvent.deploy-basic@channel.a")
+	require.Equal(t, "deployEvent", eventType)
+	require.Equal(t, "deploy-basic@channel.a", proposalID)
+
+	eventType, proposalID = ParseName("releasedEvent")
+	require.Equal(t, "releasedEvent", eventType)
+	require.Empty(t, proposalID)
+}
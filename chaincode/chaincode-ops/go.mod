@@ -3,6 +3,7 @@ module github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops
 go 1.14
 
 require (
+	github.com/Knetic/govaluate v3.0.0+incompatible // indirect
 	github.com/golang/protobuf v1.5.2
 	github.com/hyperledger/fabric v2.1.1+incompatible
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20220920210243-7bc6fa0dd58b
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AuditRecord represents a state transition of a channel update proposal, which is read from the key history.
+type AuditRecord struct {
+	// Status is the status after the transition (empty if the proposal was deleted)
+	Status string `json:"status,omitempty" metadata:",optional"`
+
+	// TxID is the ID of the transaction which made the transition
+	TxID string `json:"txID"`
+
+	// Time is the timestamp of the transaction (RFC3339)
+	Time string `json:"time"`
+
+	// Submitter describes the msp ID of the org which submitted the transaction
+	Submitter string `json:"submitter,omitempty" metadata:",optional"`
+
+	// IsDelete is true if the transaction deleted the proposal
+	IsDelete bool `json:"isDelete"`
+}
+
+// GetProposalAuditTrail returns every state transition of the channel update proposal, which is read from the key history of the ledger.
+// Note that the key history is only available on the peers with the history database enabled (core.ledger.history.enableHistoryDatabase).
+//
+// Arguments:
+//   0: proposalID - the ID of the proposal
+//
+// Returns:
+//   0: the state transitions (ordered by the timestamp of the transaction)
+//   1: error
+//
+func (s *SmartContract) GetProposalAuditTrail(ctx contractapi.TransactionContextInterface, proposalID string) ([]*AuditRecord, error) {
+	if proposalID == "" {
+		return nil, fmt.Errorf("the required parameter 'proposalID' is empty")
+	}
+
+	compositeKey, err := s.createCompositeKeyForProposal(ctx, proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("error happend creating composite key for proposal: %v", err)
+	}
+	iterator, err := ctx.GetStub().GetHistoryForKey(compositeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error happened reading the key history from ledger: %v", err)
+	}
+	defer iterator.Close()
+
+	records := []*AuditRecord{}
+	timestamps := map[*AuditRecord]time.Time{}
+	for iterator.HasNext() {
+		modification, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error happened iterating over the key history: %v", err)
+		}
+		record := &AuditRecord{
+			TxID:     modification.TxId,
+			IsDelete: modification.IsDelete,
+		}
+		if modification.Timestamp != nil {
+			timestamps[record] = time.Unix(modification.Timestamp.Seconds, int64(modification.Timestamp.Nanos))
+			record.Time = timestamps[record].UTC().Format(time.RFC3339)
+		}
+		if !modification.IsDelete {
+			var proposal Proposal
+			if err := json.Unmarshal(modification.Value, &proposal); err != nil {
+				return nil, fmt.Errorf("error happened unmarshalling a proposal JSON representation to struct: %v", err)
+			}
+			record.Status = proposal.Status
+			record.Submitter = proposal.UpdatedBy
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, ErrProposalNotFound
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return timestamps[records[i]].Before(timestamps[records[j]])
+	})
+	return records, nil
+}
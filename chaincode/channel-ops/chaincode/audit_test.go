@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/channel-ops/chaincode/mocks"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetProposalAuditTrail(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	sc := &SmartContract{}
+
+	now := time.Now().Truncate(time.Second)
+	modification := func(txID string, elapsed time.Duration, status string, updatedBy string) *queryresult.KeyModification {
+		proposalJSON, err := json.Marshal(Proposal{ObjectType: ProposalObjectType, ID: "request-1", Status: status, UpdatedBy: updatedBy})
+		require.NoError(t, err)
+		return &queryresult.KeyModification{TxId: txID, Value: proposalJSON, Timestamp: timestamppb.New(now.Add(elapsed))}
+	}
+	// The peer returns the modifications from the newest one
+	modifications := []*queryresult.KeyModification{
+		modification("tx-3", 2*time.Second, Committed, "Org2MSP"),
+		modification("tx-2", time.Second, Approved, "Org2MSP"),
+		modification("tx-1", 0, Proposed, "Org1MSP"),
+	}
+	iterator := &mocks.HistoryQueryIterator{}
+	for i, m := range modifications {
+		iterator.HasNextReturnsOnCall(i, true)
+		iterator.NextReturnsOnCall(i, m, nil)
+	}
+	iterator.HasNextReturnsOnCall(len(modifications), false)
+	chaincodeStub.GetHistoryForKeyReturns(iterator, nil)
+
+	// Case: Get the transitions of the proposal in order
+	records, err := sc.GetProposalAuditTrail(transactionContext, "request-1")
+	require.NoError(t, err)
+	expected := []*AuditRecord{
+		{Status: Proposed, TxID: "tx-1", Time: now.UTC().Format(time.RFC3339), Submitter: "Org1MSP"},
+		{Status: Approved, TxID: "tx-2", Time: now.Add(time.Second).UTC().Format(time.RFC3339), Submitter: "Org2MSP"},
+		{Status: Committed, TxID: "tx-3", Time: now.Add(2 * time.Second).UTC().Format(time.RFC3339), Submitter: "Org2MSP"},
+	}
+	require.Equal(t, expected, records)
+	require.Equal(t, "proposal_request-1", chaincodeStub.GetHistoryForKeyArgsForCall(0))
+	require.Equal(t, 1, iterator.CloseCallCount())
+
+	// Case: Fail when the proposal does not exist
+	chaincodeStub.GetHistoryForKeyReturns(&mocks.HistoryQueryIterator{}, nil)
+	_, err = sc.GetProposalAuditTrail(transactionContext, "request-unknown")
+	require.ErrorIs(t, err, ErrProposalNotFound)
+
+	// Case: Fail when the key history is not available
+	chaincodeStub.GetHistoryForKeyReturns(nil, fmt.Errorf("history database is disabled"))
+	_, err = sc.GetProposalAuditTrail(transactionContext, "request-1")
+	require.EqualError(t, err, "error happened reading the key history from ledger: history database is disabled")
+
+	// Case: Fail without the proposal ID
+	_, err = sc.GetProposalAuditTrail(transactionContext, "")
+	require.EqualError(t, err, "the required parameter 'proposalID' is empty")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/authorization"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Functionalities to authorize the identities which call the write functions on behalf of each organization.
+// The rules and their checks are shared with chaincode-ops in the authorization package.
+
+// AuthorizationRule restricts the identities which can call the function.
+type AuthorizationRule = authorization.Rule
+
+// AuthorizationConfig describes the authorization rules of an organization that is stored as a state in the ledger.
+type AuthorizationConfig = authorization.Config
+
+// Object types
+const (
+	AuthorizationConfigObjectType = authorization.ConfigObjectType
+)
+
+// AdminRole is the MSP role which can set the authorization rules of the organization.
+const AdminRole = authorization.AdminRole
+
+// authorizer authorizes the callers of the functions which can be restricted by the authorization rules.
+var authorizer = &authorization.Authorizer{
+	Functions: []string{
+		"RequestProposal", "Vote", "NotifyCommitResult",
+		"CreateChannel", "UpdateChannelType", "AddOrganization", "SetOrganizations",
+	},
+}
+
+var (
+	// ErrUnauthorized is returned when the caller is not authorized by the authorization rules of its organization.
+	ErrUnauthorized = authorization.ErrUnauthorized
+)
+
+// SetAuthorizationRules sets the authorization rules for the identities of the caller's organization.
+// This replaces the existing rules of the organization, and removes them if no rule is given.
+// This function is only available for the admin of the organization.
+//
+// Arguments:
+//   0: rules - the authorization rules (at most one rule for each function)
+//
+// Returns:
+//   0: the authorization config of the organization (if no rule is given, the func returns null)
+//   1: error
+//
+func (s *SmartContract) SetAuthorizationRules(ctx contractapi.TransactionContextInterface, rules []AuthorizationRule) (*AuthorizationConfig, error) {
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+	return authorizer.SetRules(ctx, mspID, rules, txTimestamp)
+}
+
+// GetAuthorizationConfig returns the authorization rules of the given organization.
+//
+// Arguments:
+//   0: mspID - the MSP ID of the organization
+//
+// Returns:
+//   0: the authorization config of the organization (if no rule is set, the func returns null)
+//   1: error
+//
+func (s *SmartContract) GetAuthorizationConfig(ctx contractapi.TransactionContextInterface, mspID string) (*AuthorizationConfig, error) {
+	if mspID == "" {
+		return nil, fmt.Errorf("the required parameter 'mspID' is empty")
+	}
+	return authorization.GetConfig(ctx, mspID)
+}
+
+// -- Internal logics
+
+// authorize checks that the caller is allowed to call the function by the authorization rules of its organization.
+func (s *SmartContract) authorize(ctx contractapi.TransactionContextInterface, function string) error {
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	return authorizer.Authorize(ctx, mspID, function)
+}
+
+// getIdentityID returns the ID of the client identity, which is unique within the MSP.
+func getIdentityID(ctx contractapi.TransactionContextInterface) (string, error) {
+	return authorization.IdentityID(ctx)
+}
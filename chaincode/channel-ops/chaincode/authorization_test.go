@@ -0,0 +1,126 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/channel-ops/chaincode/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newClientIdentity returns a client identity with the given ID, MSP roles (OUs) and certificate attributes.
+func newClientIdentity(id string, roles []string, attributes map[string]string) *mocks.ClientIdentity {
+	identity := &mocks.ClientIdentity{}
+	identity.GetIDReturns(id, nil)
+	identity.GetX509CertificateReturns(&x509.Certificate{Subject: pkix.Name{OrganizationalUnit: roles}}, nil)
+	identity.GetAttributeValueStub = func(name string) (string, bool, error) {
+		value, found := attributes[name]
+		return value, found, nil
+	}
+	return identity
+}
+
+func TestAuthorize(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	now := time.Now().Truncate(time.Second)
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(now), nil)
+	worldState := map[string][]byte{}
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return worldState[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		worldState[key] = value
+		return nil
+	}
+	chaincodeStub.DelStateStub = func(key string) error {
+		delete(worldState, key)
+		return nil
+	}
+
+	sc := SmartContract{}
+	admin := newClientIdentity("org2-admin", []string{"admin"}, nil)
+	voter := newClientIdentity("org2-voter", []string{"client"}, map[string]string{"opssc.role": "voter"})
+	client := newClientIdentity("org2-client", []string{"client"}, nil)
+
+	// Prepare: Org2 restricts the votes to the voters, and the channel creation to the admins
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	transactionContext.GetClientIdentityReturns(admin)
+	rules := []AuthorizationRule{
+		{Function: "Vote", Attributes: []string{"opssc.role=voter"}},
+		{Function: "CreateChannel", Roles: []string{"admin"}},
+	}
+	config, err := sc.SetAuthorizationRules(transactionContext, rules)
+	require.NoError(t, err)
+	require.Equal(t, &AuthorizationConfig{
+		ObjectType:  AuthorizationConfigObjectType,
+		MSPID:       "Org2MSP",
+		Rules:       rules,
+		UpdatedByID: "org2-admin",
+		Time:        now.UTC().Format(time.RFC3339),
+	}, config)
+
+	// Case: Fail to set the rules by the identity other than the admin
+	transactionContext.GetClientIdentityReturns(client)
+	_, err = sc.SetAuthorizationRules(transactionContext, nil)
+	require.ErrorIs(t, err, ErrUnauthorized)
+	_, err = sc.SetAuthorizationRules(transactionContext, []AuthorizationRule{{Function: "GetProposal", Roles: []string{"admin"}}})
+	require.EqualError(t, err, "the function 'GetProposal' is not supported by the authorization rules")
+
+	// Case: Fail to create a channel by the identity without the role
+	err = sc.CreateChannel(transactionContext, "mychannel", ApplicationChannelType, []string{"Org1MSP", "Org2MSP", "Org3MSP"})
+	require.ErrorIs(t, err, ErrUnauthorized)
+	require.EqualError(t, err, "the identity is not authorized to call CreateChannel: it should have any of the roles [admin]")
+
+	// Case: The identities of the other orgs are not restricted, and the identity IDs are recorded
+	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	transactionContext.GetClientIdentityReturns(newClientIdentity("org1-client", []string{"client"}, nil))
+	require.NoError(t, sc.CreateChannel(transactionContext, "mychannel", ApplicationChannelType, []string{"Org1MSP", "Org2MSP", "Org3MSP"}))
+	_, err = sc.RequestProposal(transactionContext, ProposalInput{
+		ID:           "request-1",
+		ChannelID:    "mychannel",
+		ConfigUpdate: updateBase64,
+		Signature:    signatureBase64,
+	})
+	require.NoError(t, err)
+	proposal, err := sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, "org1-client", proposal.CreatorID)
+	require.Equal(t, "org1-client", proposal.UpdatedByID)
+
+	// Case: Fail to vote by the identity without the attribute
+	chaincodeStub.GetCreatorReturns(org2MSP, nil)
+	transactionContext.GetClientIdentityReturns(client)
+	err = sc.Vote(transactionContext, "request-1", signatureBase64)
+	require.EqualError(t, err, "the identity is not authorized to call Vote: it should have any of the attributes [opssc.role=voter]")
+
+	// Case: Vote by the identity with the attribute
+	transactionContext.GetClientIdentityReturns(voter)
+	require.NoError(t, sc.Vote(transactionContext, "request-1", signatureBase64))
+	proposal, err = sc.GetProposal(transactionContext, "request-1")
+	require.NoError(t, err)
+	require.Equal(t, Approved, proposal.Status)
+	require.Equal(t, "org1-client", proposal.CreatorID)
+	require.Equal(t, "org2-voter", proposal.UpdatedByID)
+
+	// Case: Remove the rules by the admin
+	transactionContext.GetClientIdentityReturns(admin)
+	config, err = sc.SetAuthorizationRules(transactionContext, nil)
+	require.NoError(t, err)
+	require.Nil(t, config)
+	config, err = sc.GetAuthorizationConfig(transactionContext, "Org2MSP")
+	require.NoError(t, err)
+	require.Nil(t, config)
+	require.NotContains(t, worldState, "authorizationConfig_Org2MSP")
+}
@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -53,6 +54,18 @@ type Proposal struct {
 
 	// Artifacts contains the artifacts for the channel update proposal
 	Artifacts Artifacts `json:"artifacts"`
+
+	// Time is the time when the proposal is requested (RFC3339)
+	Time string `json:"time,omitempty" metadata:",optional"`
+
+	// UpdatedBy describes the msp ID of the org which submitted the last update of the proposal
+	UpdatedBy string `json:"updatedBy,omitempty" metadata:",optional"`
+
+	// CreatorID describes the identity ID of the proposal creator
+	CreatorID string `json:"creatorID,omitempty" metadata:",optional"`
+
+	// UpdatedByID describes the identity ID of the caller which submitted the last update of the proposal
+	UpdatedByID string `json:"updatedByID,omitempty" metadata:",optional"`
 }
 
 // Artifacts contains artifacts for a channel update proposal
@@ -134,10 +147,14 @@ var (
 //
 // Events:
 //   name: newProposalEvent(<proposalID>)
-//   payload: the created proposal ID
+//   payload: nil
 //
 func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterface, input ProposalInput) (string, error) {
 
+	if err := s.authorize(ctx, "RequestProposal"); err != nil {
+		return "", err
+	}
+
 	proposalID := input.ID
 	action := input.Action
 	if action == "" {
@@ -164,6 +181,16 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 		return "", fmt.Errorf("failed to get MSP ID: %v", err)
 	}
 
+	identityID, err := getIdentityID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the transaction timestamp: %v", err)
+	}
+
 	signatures := make(map[string]string)
 	signatures[mspID] = input.Signature
 
@@ -173,6 +200,7 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 		ChannelID:   input.ChannelID,
 		Description: input.Description,
 		Creator:     mspID,
+		CreatorID:   identityID,
 		Action:      action,
 		Status:      Proposed,
 		OpsProfile:  input.OpsProfile,
@@ -180,14 +208,15 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 			ConfigUpdate: input.ConfigUpdate,
 			Signatures:   signatures,
 		},
+		Time: txTime,
 	}
 
 	if err = s.putProposal(ctx, proposal); err != nil {
 		return "", fmt.Errorf("failed to put the proposal: %v", err)
 	}
 
-	if err = ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", NewProposalEvent, proposalID), []byte(proposalID)); err != nil {
-		return "", fmt.Errorf("error happened emitting event: %v", err)
+	if err = s.setEvent(ctx, NewProposalEvent, proposalID, nil); err != nil {
+		return "", err
 	}
 	return proposalID, nil
 }
@@ -209,10 +238,14 @@ func (s *SmartContract) RequestProposal(ctx contractapi.TransactionContextInterf
 //   payload: EventDetail
 //   (else)
 //   name: NewVoteEvent(<proposalID>)
-//   payload: proposalID
+//   payload: nil
 //
 func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, proposalID, signature string) error {
 
+	if err := s.authorize(ctx, "Vote"); err != nil {
+		return err
+	}
+
 	if err := validateConfigSignature(signature); err != nil {
 		return err
 	}
@@ -233,8 +266,8 @@ func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, propos
 	proposal.Artifacts.Signatures[mspID] = signature
 
 	// NewVoteEvent is the default event for when the proposal status does not change.
-	eventName := fmt.Sprintf("%s.%s", NewVoteEvent, proposalID)
-	eventPayload := []byte(proposalID)
+	eventType := NewVoteEvent
+	var eventData interface{}
 
 	// If votes meet the criteria, it changes the proposal status to "approved" and sets ReadyToUpdateConfigEvent.
 	if proposal.Status == Proposed {
@@ -245,23 +278,17 @@ func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, propos
 		if satisfied {
 			proposal.Status = Approved
 
-			eventDetail := EventDetail{
+			eventType = ReadyToUpdateConfigEvent
+			eventData = EventDetail{
 				ProposalID:       proposalID,
 				OperationTargets: []string{mspID},
 			}
-			// struct to JSON
-			eventDetailJSON, err := json.Marshal(eventDetail)
-			if err != nil {
-				return fmt.Errorf("error happened creating event detail: %v", err)
-			}
-			eventName = fmt.Sprintf("%s.%s", ReadyToUpdateConfigEvent, proposalID)
-			eventPayload = []byte(eventDetailJSON)
 		}
 	}
 
 	// Set event on the response of the transaction
-	if err = ctx.GetStub().SetEvent(eventName, eventPayload); err != nil {
-		return fmt.Errorf("error happened emitting event: %v", err)
+	if err = s.setEvent(ctx, eventType, proposalID, eventData); err != nil {
+		return err
 	}
 
 	// store the updated proposal
@@ -286,10 +313,14 @@ func (s *SmartContract) Vote(ctx contractapi.TransactionContextInterface, propos
 // Events:
 //   (if the channel info is updated)
 //   name: UpdateConfigEvent(<proposalID>)
-//   payload: proposalID
+//   payload: nil
 //
 func (s *SmartContract) NotifyCommitResult(ctx contractapi.TransactionContextInterface, proposalID string) error {
 
+	if err := s.authorize(ctx, "NotifyCommitResult"); err != nil {
+		return err
+	}
+
 	proposal, err := s.GetProposal(ctx, proposalID)
 	if err != nil {
 		return fmt.Errorf("failed to get proposal: %v", err)
@@ -350,19 +381,19 @@ func (s *SmartContract) NotifyCommitResult(ctx contractapi.TransactionContextInt
 
 			// Update channel info
 			if channelExists {
-				err = s.SetOrganizations(ctx, proposal.ChannelID, organizations)
+				err = s.setOrganizations(ctx, proposal.ChannelID, organizations)
 				if err != nil {
 					return fmt.Errorf("error happend updating channel info: %v", err)
 				}
 			} else {
-				err = s.CreateChannel(ctx, proposal.ChannelID, "", organizations)
+				err = s.createChannel(ctx, proposal.ChannelID, "", organizations)
 				if err != nil {
 					return fmt.Errorf("error happend creating channel info: %v", err)
 				}
 			}
 
-			if err = ctx.GetStub().SetEvent(fmt.Sprintf("%s.%s", UpdateConfigEvent, proposalID), []byte(proposalID)); err != nil {
-				return fmt.Errorf("error happened emitting event: %v", err)
+			if err = s.setEvent(ctx, UpdateConfigEvent, proposalID, nil); err != nil {
+				return err
 			}
 		}
 	}
@@ -451,6 +482,15 @@ func getMSPID(creator []byte) (string, error) {
 	return identity.Mspid, nil
 }
 
+func getTxTimestampRFC3339(ctx contractapi.TransactionContextInterface) (string, error) {
+	timestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+	tm := time.Unix(timestamp.Seconds, int64(timestamp.Nanos))
+	return tm.UTC().Format(time.RFC3339), nil
+}
+
 func validateConfigUpdate(input ProposalInput) error {
 	// check if the configUpdate is in the correct format: base64 encoded proto/common.ConfigUpdate
 	update, err := base64.StdEncoding.DecodeString(input.ConfigUpdate)
@@ -521,6 +561,16 @@ func (s *SmartContract) meetCriteria(ctx contractapi.TransactionContextInterface
 }
 
 func (s *SmartContract) putProposal(ctx contractapi.TransactionContextInterface, proposal *Proposal) error {
+	// Record the submitter of the update, which is kept in the key history for the audit trail
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	proposal.UpdatedBy = mspID
+	if proposal.UpdatedByID, err = getIdentityID(ctx); err != nil {
+		return err
+	}
+
 	proposalJSON, err := json.Marshal(proposal)
 	if err != nil {
 		return fmt.Errorf("error happened marshalling the new proposal: %v", err)
@@ -12,15 +12,19 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/events"
 	"github.com/hyperledger-labs/fabric-opssc/chaincode/channel-ops/chaincode/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -101,16 +105,36 @@ type stateQueryIterator interface {
 	shim.StateQueryIteratorInterface
 }
 
+//go:generate counterfeiter -o mocks/historyqueryiterator.go -fake-name HistoryQueryIterator . historyQueryIterator
+type historyQueryIterator interface {
+	shim.HistoryQueryIteratorInterface
+}
+
+//go:generate counterfeiter -o mocks/clientidentity.go -fake-name ClientIdentity . clientIdentity
+type clientIdentity interface {
+	cid.ClientIdentity
+}
+
 // Dummy implementation to create compose key
 func createComposeKey(objectType string, keys []string) (string, error) {
 	return objectType + "_" + strings.Join(keys, "_"), nil
 }
 
+// decodeEvent decodes the envelope of the given event payload.
+func decodeEvent(t *testing.T, eventPayload []byte) events.Envelope {
+	envelope, err := events.Decode(eventPayload)
+	require.NoError(t, err)
+	return *envelope
+}
+
 func TestRequestProposal(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	now := timestamppb.Now()
+	chaincodeStub.GetTxTimestampReturns(now, nil)
 
 	sc := SmartContract{}
 
@@ -143,6 +167,8 @@ func TestRequestProposal(t *testing.T) {
 			ConfigUpdate: input.ConfigUpdate,
 			Signatures:   expectedSignatures,
 		},
+		Time:      time.Unix(now.Seconds, int64(now.Nanos)).UTC().Format(time.RFC3339),
+		UpdatedBy: "Org1MSP",
 	}
 	expectedJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -150,8 +176,14 @@ func TestRequestProposal(t *testing.T) {
 	require.Equal(t, input.ID, actualID)
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "newProposalEvent.request-1", eventName)
-	expectedEventPayload := []byte(input.ID)
-	require.Equal(t, string(expectedEventPayload), string(eventPayload))
+	envelope := decodeEvent(t, eventPayload)
+	require.Equal(t, events.SpecVersion, envelope.SpecVersion)
+	require.Equal(t, NewProposalEvent, envelope.Type)
+	require.Equal(t, events.ChannelOpsSource, envelope.Source)
+	require.Equal(t, input.ID, envelope.ProposalID)
+	require.Equal(t, "Org1MSP", envelope.Actor)
+	require.Equal(t, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Format(time.RFC3339), envelope.Time)
+	require.Nil(t, envelope.Data)
 
 	// Case: Fail to request when an invalid action is inputted
 	input = ProposalInput{
@@ -208,8 +240,15 @@ func TestRequestProposal(t *testing.T) {
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.EqualError(t, err, "failed to get MSP ID: error happened reading the transaction creator: failed to get MSP ID")
 
-	// Case: Fail to request when putProposal occurs an error
+	// Case: Fail to request when getting the transaction timestamp is failed
 	chaincodeStub.GetCreatorReturns(org1MSP, nil)
+	chaincodeStub.GetTxTimestampReturns(nil, fmt.Errorf("failed to get timestamp"))
+	_, err = sc.RequestProposal(transactionContext, input)
+	require.EqualError(t, err, "failed to get the transaction timestamp: failed to get timestamp")
+
+	// Case: Fail to request when putProposal occurs an error
+	chaincodeStub.GetTxTimestampReturns(now, nil)
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(chaincodeStub.CreateCompositeKeyCallCount(), AuthorizationConfigObjectType, nil)
 	chaincodeStub.CreateCompositeKeyReturns("", fmt.Errorf("failed to create composite key"))
 	_, err = sc.RequestProposal(transactionContext, input)
 	require.EqualError(t, err, "failed to put the proposal: error happend creating composite key for proposal: failed to create composite key")
@@ -225,7 +264,9 @@ func TestVote(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
 	sc := SmartContract{}
 
 	proposalID := "request-1"
@@ -247,7 +288,7 @@ func TestVote(t *testing.T) {
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	baseChannel := Channel{
 		ObjectType:  ChannelObjectType,
@@ -260,7 +301,7 @@ func TestVote(t *testing.T) {
 	}
 	baseChannelJSON, err := json.Marshal(baseChannel)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(1, baseChannelJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(2, baseChannelJSON, nil)
 	iterator := &mocks.StateQueryIterator{}
 	iterator.HasNextReturnsOnCall(0, true)
 	iterator.HasNextReturnsOnCall(1, false)
@@ -283,14 +324,18 @@ func TestVote(t *testing.T) {
 			ConfigUpdate: updateBase64,
 			Signatures:   map[string]string{"Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
 	require.JSONEq(t, string(expectedJSON), string(state))
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "newVoteEvent.request-1", eventName)
-	expectedEventPayload := []byte(proposalID)
-	require.Equal(t, string(expectedEventPayload), string(eventPayload))
+	envelope := decodeEvent(t, eventPayload)
+	require.Equal(t, NewVoteEvent, envelope.Type)
+	require.Equal(t, proposalID, envelope.ProposalID)
+	require.Equal(t, "Org2MSP", envelope.Actor)
+	require.Nil(t, envelope.Data)
 
 	// Case: Request a vote to a proposal (to update a channel) and the votes meet the majority
 	chaincodeStub.GetCreatorReturns(org2MSP, nil)
@@ -309,7 +354,7 @@ func TestVote(t *testing.T) {
 	}
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(2, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(4, baseProposalJSON, nil)
 
 	baseChannel = Channel{
 		ObjectType:  ChannelObjectType,
@@ -322,7 +367,7 @@ func TestVote(t *testing.T) {
 	}
 	baseChannelJSON, err = json.Marshal(baseChannel)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(3, baseChannelJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(5, baseChannelJSON, nil)
 
 	err = sc.Vote(transactionContext, proposalID, signatureBase64)
 	require.NoError(t, err)
@@ -340,6 +385,7 @@ func TestVote(t *testing.T) {
 			ConfigUpdate: updateBase64,
 			Signatures:   map[string]string{"Org1MSP": signatureBase64, "Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedJSON, err = json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -353,27 +399,27 @@ func TestVote(t *testing.T) {
 	}
 	expectedEventPayloadJSON, err := json.Marshal(expectedReadyToUpdateConfigEventPayload)
 	require.NoError(t, err)
-	require.Equal(t, string(expectedEventPayloadJSON), string(eventPayload))
+	require.Equal(t, string(expectedEventPayloadJSON), string(decodeEvent(t, eventPayload).Data))
 
 	// Case: Fail to vote when setEvent occurs an error
-	chaincodeStub.GetStateReturnsOnCall(4, baseProposalJSON, nil)
-	chaincodeStub.GetStateReturnsOnCall(5, baseChannelJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(7, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(8, baseChannelJSON, nil)
 	chaincodeStub.SetEventReturns(fmt.Errorf("failed to set event"))
 	err = sc.Vote(transactionContext, proposalID, signatureBase64)
 	require.EqualError(t, err, "error happened emitting event: failed to set event")
 
 	// Case: Fail to request when putProposal occurs an error
-	chaincodeStub.GetStateReturnsOnCall(6, baseProposalJSON, nil)
-	chaincodeStub.GetStateReturnsOnCall(7, baseChannelJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(10, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(11, baseChannelJSON, nil)
 	chaincodeStub.SetEventReturns(nil)
 	cc := chaincodeStub.CreateCompositeKeyCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(cc+2, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(cc+3, "", fmt.Errorf("failed to create composite key"))
 	err = sc.Vote(transactionContext, proposalID, signatureBase64)
 	require.EqualError(t, err, "failed to put the proposal: error happend creating composite key for proposal: failed to create composite key")
 
 	// Case: Fail to vote when checking number of votes fails
-	chaincodeStub.GetStateReturnsOnCall(8, baseProposalJSON, nil)
-	chaincodeStub.GetStateReturnsOnCall(9, nil, fmt.Errorf("failed to get state"))
+	chaincodeStub.GetStateReturnsOnCall(13, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(14, nil, fmt.Errorf("failed to get state"))
 	err = sc.Vote(transactionContext, proposalID, signatureBase64)
 	require.EqualError(t, err, "fail to check whether the votes passed: error happened checking to meet criteria: fail to get the num of organizations: failed to read channel: failed to read from world state: failed to get state")
 
@@ -397,7 +443,9 @@ func TestNotifyCommitResult(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
 	sc := SmartContract{}
 
 	// Case: Notify commit result to update an application channel (without updating the organizations)
@@ -417,7 +465,7 @@ func TestNotifyCommitResult(t *testing.T) {
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.NoError(t, err)
@@ -435,6 +483,7 @@ func TestNotifyCommitResult(t *testing.T) {
 			ConfigUpdate: updateBase64,
 			Signatures:   map[string]string{"Org1MSP": signatureBase64, "Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedProposalJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -446,7 +495,9 @@ func TestNotifyCommitResultWhenSetEventFails(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
 	sc := SmartContract{}
 
 	// Case: Fail to notify commit result when set event fails
@@ -467,7 +518,7 @@ func TestNotifyCommitResultWhenSetEventFails(t *testing.T) {
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.EqualError(t, err, "error happened emitting event: failed to set event")
@@ -477,7 +528,9 @@ func TestNotifyCommitResultToCreateAppChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
 	sc := SmartContract{}
 
 	// Case: Notify commit result to create an application channel
@@ -497,7 +550,7 @@ func TestNotifyCommitResultToCreateAppChannel(t *testing.T) {
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.NoError(t, err)
@@ -515,6 +568,7 @@ func TestNotifyCommitResultToCreateAppChannel(t *testing.T) {
 			ConfigUpdate: updateOrgsInAppChannelBase64,
 			Signatures:   map[string]string{"Org1MSP": signatureBase64, "Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedProposalJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -537,7 +591,9 @@ func TestNotifyCommitResultToUpdateOrgsInSystemChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
 	sc := SmartContract{}
 
 	// Case: Notify commit result to update an system channel (the update includes changing organizations)
@@ -557,7 +613,7 @@ func TestNotifyCommitResultToUpdateOrgsInSystemChannel(t *testing.T) {
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	baseChannel := Channel{
 		ObjectType:  ChannelObjectType,
@@ -570,8 +626,8 @@ func TestNotifyCommitResultToUpdateOrgsInSystemChannel(t *testing.T) {
 	}
 	baseChannelJSON, err := json.Marshal(baseChannel)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(1, baseChannelJSON, nil)
 	chaincodeStub.GetStateReturnsOnCall(2, baseChannelJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(3, baseChannelJSON, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.NoError(t, err)
@@ -589,6 +645,7 @@ func TestNotifyCommitResultToUpdateOrgsInSystemChannel(t *testing.T) {
 			ConfigUpdate: updateOrgsInSystemChannelBase64,
 			Signatures:   map[string]string{"Org1MSP": signatureBase64, "Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedProposalJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -606,13 +663,17 @@ func TestNotifyCommitResultToUpdateOrgsInSystemChannel(t *testing.T) {
 	require.JSONEq(t, string(expectedChannelJSON), string(state))
 	eventName, eventPayload := chaincodeStub.SetEventArgsForCall(0)
 	require.Equal(t, "updateConfigEvent.request-1", eventName)
-	require.Equal(t, string("request-1"), string(eventPayload))
+	envelope := decodeEvent(t, eventPayload)
+	require.Equal(t, UpdateConfigEvent, envelope.Type)
+	require.Equal(t, "request-1", envelope.ProposalID)
+	require.Nil(t, envelope.Data)
 }
 
 func TestNotifyCommitResultToUpdateOrgsWhenTheConsortiumGroupIsMissing(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	sc := SmartContract{}
 
@@ -645,7 +706,7 @@ func TestNotifyCommitResultToUpdateOrgsWhenTheConsortiumGroupIsMissing(t *testin
 	}
 	baseProposalJSON, err := json.Marshal(baseProposal)
 	require.NoError(t, err)
-	chaincodeStub.GetStateReturnsOnCall(0, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, baseProposalJSON, nil)
 
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.NoError(t, err)
@@ -663,6 +724,7 @@ func TestNotifyCommitResultToUpdateOrgsWhenTheConsortiumGroupIsMissing(t *testin
 			ConfigUpdate: updateMissingGroupBase64,
 			Signatures:   map[string]string{"Org1MSP": signatureBase64, "Org2MSP": signatureBase64},
 		},
+		UpdatedBy: "Org2MSP",
 	}
 	expectedProposalJSON, err := json.Marshal(expectedProposal)
 	require.NoError(t, err)
@@ -674,6 +736,7 @@ func TestNotifyCommitResultFailure(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 	sc := SmartContract{}
 
@@ -719,9 +782,9 @@ func TestNotifyCommitResultFailure(t *testing.T) {
 	}
 	baseProposalJSON, err = json.Marshal(baseProposal)
 	gsCallCount := chaincodeStub.GetStateCallCount()
-	chaincodeStub.GetStateReturnsOnCall(gsCallCount, baseProposalJSON, nil)
+	chaincodeStub.GetStateReturnsOnCall(gsCallCount+1, baseProposalJSON, nil)
 	ccCallCount := chaincodeStub.GetStateCallCount()
-	chaincodeStub.CreateCompositeKeyReturnsOnCall(ccCallCount+1, "", fmt.Errorf("failed to create composite key"))
+	chaincodeStub.CreateCompositeKeyReturnsOnCall(ccCallCount+2, "", fmt.Errorf("failed to create composite key"))
 	err = sc.NotifyCommitResult(transactionContext, "request-1")
 	require.EqualError(t, err, "failed to put proposal: error happend creating composite key for proposal: failed to create composite key")
 
@@ -752,6 +815,7 @@ func TestGetAllProposals(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 
 	// Case: Get 2 proposals
@@ -817,6 +881,7 @@ func TestGetProposal(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	sc := &SmartContract{}
 
 	// Case: Get the proposal
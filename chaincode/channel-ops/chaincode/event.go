@@ -0,0 +1,50 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops/events"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// setEvent emits the chaincode event named "<eventType>.<proposalID>", whose payload is the versioned envelope defined in the events package of chaincode-ops.
+// The envelope has no data if the given data is nil.
+func (s *SmartContract) setEvent(ctx contractapi.TransactionContextInterface, eventType string, proposalID string, data interface{}) error {
+	mspID, err := s.getMSPID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get MSP ID: %v", err)
+	}
+	txTimestamp, err := getTxTimestampRFC3339(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tx timestamp: %v", err)
+	}
+
+	envelope := events.Envelope{
+		SpecVersion: events.SpecVersion,
+		Type:        eventType,
+		Source:      events.ChannelOpsSource,
+		ProposalID:  proposalID,
+		ChannelID:   ctx.GetStub().GetChannelID(),
+		Actor:       mspID,
+		TxID:        ctx.GetStub().GetTxID(),
+		Time:        txTimestamp,
+	}
+	if err := envelope.SetData(data); err != nil {
+		return fmt.Errorf("error happened marshalling the event data: %v", err)
+	}
+	envelopeJSON, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("error happened marshalling the event envelope: %v", err)
+	}
+	if err = ctx.GetStub().SetEvent(envelope.Name(), envelopeJSON); err != nil {
+		return fmt.Errorf("error happened emitting event: %v", err)
+	}
+	return nil
+}
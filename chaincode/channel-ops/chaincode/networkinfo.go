@@ -55,6 +55,14 @@ const (
 //   0: error
 //
 func (s *SmartContract) CreateChannel(ctx contractapi.TransactionContextInterface, channelID string, channelType string, mspIDs []string) error {
+	if err := s.authorize(ctx, "CreateChannel"); err != nil {
+		return err
+	}
+	return s.createChannel(ctx, channelID, channelType, mspIDs)
+}
+
+// createChannel issues a new channel information without the authorization of the caller.
+func (s *SmartContract) createChannel(ctx contractapi.TransactionContextInterface, channelID string, channelType string, mspIDs []string) error {
 
 	exists, err := s.ChannelExists(ctx, channelID)
 	if err != nil {
@@ -98,6 +106,10 @@ func (s *SmartContract) CreateChannel(ctx contractapi.TransactionContextInterfac
 //
 func (s *SmartContract) UpdateChannelType(ctx contractapi.TransactionContextInterface, channelID string, channelType string) error {
 
+	if err := s.authorize(ctx, "UpdateChannelType"); err != nil {
+		return err
+	}
+
 	channel, err := s.ReadChannel(ctx, channelID)
 	if err != nil {
 		return fmt.Errorf("failed to read channel: %v", err)
@@ -121,6 +133,10 @@ func (s *SmartContract) UpdateChannelType(ctx contractapi.TransactionContextInte
 //   0: error
 //
 func (s *SmartContract) AddOrganization(ctx contractapi.TransactionContextInterface, channelID string, mspID string) error {
+	if err := s.authorize(ctx, "AddOrganization"); err != nil {
+		return err
+	}
+
 	channel, err := s.ReadChannel(ctx, channelID)
 	if err != nil {
 		return fmt.Errorf("failed to read channel: %v", err)
@@ -144,6 +160,14 @@ func (s *SmartContract) AddOrganization(ctx contractapi.TransactionContextInterf
 //   0: error
 //
 func (s *SmartContract) SetOrganizations(ctx contractapi.TransactionContextInterface, channelID string, mspIDs []string) error {
+	if err := s.authorize(ctx, "SetOrganizations"); err != nil {
+		return err
+	}
+	return s.setOrganizations(ctx, channelID, mspIDs)
+}
+
+// setOrganizations replaces the members of the given channel without the authorization of the caller.
+func (s *SmartContract) setOrganizations(ctx contractapi.TransactionContextInterface, channelID string, mspIDs []string) error {
 	channel, err := s.ReadChannel(ctx, channelID)
 	if err != nil {
 		return fmt.Errorf("failed to read channel: %v", err)
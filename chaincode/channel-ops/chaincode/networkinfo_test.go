@@ -44,6 +44,7 @@ func TestCreateChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 	chaincodeStub.CreateCompositeKeyStub = createComposeKey
 
 	sc := chaincode.SmartContract{}
@@ -89,11 +90,13 @@ func TestCreateChannel(t *testing.T) {
 
 	// Case: Create a duplicated channel
 	chaincodeStub.GetStateReturns([]byte{}, nil)
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), nil, nil)
 	err = sc.CreateChannel(transactionContext, "mychannel", chaincode.ApplicationChannelType, []string{"Org1MSP", "Org2MSP"})
 	require.EqualError(t, err, "the channel mychannel already exists")
 
 	// Case: Internal state read error
 	chaincodeStub.GetStateReturns(nil, fmt.Errorf("unable to retrieve channel"))
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), nil, nil)
 	err = sc.CreateChannel(transactionContext, "mychannel", chaincode.ApplicationChannelType, []string{"Org1MSP", "Org2MSP"})
 	require.EqualError(t, err, "failed to read from world state: unable to retrieve channel")
 }
@@ -102,6 +105,7 @@ func TestReadChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Case: Read a channel
 	expected := chaincode.Channel{
@@ -145,6 +149,7 @@ func TestChannelExists(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Case: A channel is found
 	expected := chaincode.Channel{
@@ -174,6 +179,7 @@ func TestAddOrganization(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Case: Add an organization to a channel with some organizations
 	channel := chaincode.Channel{
@@ -236,6 +242,7 @@ func TestAddOrganization(t *testing.T) {
 
 	// Case: Internal state read error
 	chaincodeStub.GetStateReturns(nil, fmt.Errorf("unable to retrieve channel"))
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), nil, nil)
 	err = sc.AddOrganization(transactionContext, "mychannel", "Org3MSP")
 	require.EqualError(t, err, "failed to read channel: failed to read from world state: unable to retrieve channel")
 }
@@ -244,6 +251,7 @@ func TestSetOrganizations(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	channel := chaincode.Channel{
 		ObjectType:  chaincode.ChannelObjectType,
@@ -277,6 +285,7 @@ func TestSetOrganizations(t *testing.T) {
 	require.EqualError(t, err, "failed to read channel: the channel mychannel does not exist")
 
 	chaincodeStub.GetStateReturns(nil, fmt.Errorf("unable to retrieve channel"))
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), nil, nil)
 	err = sc.SetOrganizations(transactionContext, "mychannel", []string{"Org2MSP", "Org3MSP"})
 	require.EqualError(t, err, "failed to read channel: failed to read from world state: unable to retrieve channel")
 }
@@ -285,6 +294,7 @@ func TestCountOrganizationsInChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	channel := chaincode.Channel{
 		ObjectType:  chaincode.ChannelObjectType,
@@ -316,6 +326,7 @@ func TestGetOrganizationsInChannel(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	channel := chaincode.Channel{
 		ObjectType:  chaincode.ChannelObjectType,
@@ -376,6 +387,7 @@ func TestGetAllChannels(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
 	sc := &chaincode.SmartContract{}
@@ -399,6 +411,7 @@ func TestGetSystemChannelID(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Positive case
 	channel := chaincode.Channel{
@@ -448,6 +461,7 @@ func TestUpdateChannelType(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Positive case
 	channel := chaincode.Channel{
@@ -486,6 +500,7 @@ func TestUpdateChannelType(t *testing.T) {
 
 	// Error case: Update an unavailable channel
 	chaincodeStub.GetStateReturns(nil, fmt.Errorf("unable to retrieve channel"))
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), nil, nil)
 	err = sc.UpdateChannelType(transactionContext, "system-channel", chaincode.DisableChannelType)
 	require.EqualError(t, err, "failed to read channel: failed to read from world state: unable to retrieve channel")
 }
@@ -494,6 +509,7 @@ func TestGetChannelType(t *testing.T) {
 	chaincodeStub := &mocks.ChaincodeStub{}
 	transactionContext := &mocks.TransactionContext{}
 	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
 
 	// Positive case
 	channel := chaincode.Channel{
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 Hitachi, Ltd., Hitachi America, Ltd. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-opssc/chaincode/channel-ops/chaincode/mocks"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// paginatedProposals returns a dummy implementation of GetStateByPartialCompositeKeyWithPagination over the given proposals.
+func paginatedProposals(t *testing.T, proposals []Proposal) func(string, []string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	kvs := []*queryresult.KV{}
+	for _, proposal := range proposals {
+		proposalJSON, err := json.Marshal(proposal)
+		require.NoError(t, err)
+		key, _ := createComposeKey(ProposalObjectType, []string{proposal.ID})
+		kvs = append(kvs, &queryresult.KV{Key: key, Value: proposalJSON})
+	}
+
+	return func(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+		page := []*queryresult.KV{}
+		nextBookmark := ""
+		for _, kv := range kvs {
+			if kv.Key < bookmark {
+				continue
+			}
+			if int32(len(page)) == pageSize {
+				nextBookmark = kv.Key
+				break
+			}
+			page = append(page, kv)
+		}
+
+		iterator := &mocks.StateQueryIterator{}
+		index := 0
+		iterator.HasNextStub = func() bool {
+			return index < len(page)
+		}
+		iterator.NextStub = func() (*queryresult.KV, error) {
+			index++
+			return page[index-1], nil
+		}
+		return iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: int32(len(page)), Bookmark: nextBookmark}, nil
+	}
+}
+
+func TestQueryProposals(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	sc := &SmartContract{}
+
+	// Prepare proposals: request-1..4 by Org1MSP for mychannel and request-5 by Org2MSP for system-channel (one per hour)
+	proposals := []Proposal{}
+	for i := 1; i <= 5; i++ {
+		proposal := Proposal{
+			ObjectType: ProposalObjectType,
+			ID:         fmt.Sprintf("request-%d", i),
+			Creator:    "Org1MSP",
+			ChannelID:  "mychannel",
+			Action:     UpdateAction,
+			Status:     Proposed,
+			Artifacts: Artifacts{
+				ConfigUpdate: updateBase64,
+				Signatures:   map[string]string{"Org1MSP": signatureBase64},
+			},
+			Time: fmt.Sprintf("2026-01-01T0%d:00:00Z", i),
+		}
+		if i == 5 {
+			proposal.Creator = "Org2MSP"
+			proposal.ChannelID = "system-channel"
+			proposal.Status = Committed
+		}
+		proposals = append(proposals, proposal)
+	}
+	chaincodeStub.GetStateByPartialCompositeKeyWithPaginationStub = paginatedProposals(t, proposals)
+
+	proposalIDs := func(result *ProposalQueryResult) []string {
+		ids := []string{}
+		for _, proposal := range result.Proposals {
+			ids = append(ids, proposal.ID)
+		}
+		return ids
+	}
+
+	// Case: Get all proposals with the default page size
+	result, err := sc.QueryProposals(transactionContext, ProposalQueryParams{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1", "request-2", "request-3", "request-4", "request-5"}, proposalIDs(result))
+	require.Equal(t, &proposals[0], result.Proposals[0])
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals page by page
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-1", "request-2"}, proposalIDs(result))
+	require.Equal(t, "proposal_request-3", result.Bookmark)
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 2, Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-3", "request-4"}, proposalIDs(result))
+	require.Equal(t, "proposal_request-5", result.Bookmark)
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 2, Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-5"}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals filtered by the status, the channel ID and the creator across multiple ledger pages
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: 2, Status: Committed, ChannelID: "system-channel", Creator: "Org2MSP"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-5"}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Get proposals filtered by the time range
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{From: "2026-01-01T02:00:00Z", To: "2026-01-01T04:00:00Z"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"request-2", "request-3"}, proposalIDs(result))
+
+	// Case: The call stops scanning at the limit and returns the bookmark even if the page is not full
+	manyProposals := []Proposal{}
+	for i := 1; i <= MaxScannedRecords+1; i++ {
+		proposal := proposals[0]
+		proposal.ID = fmt.Sprintf("request-%04d", i)
+		if i == MaxScannedRecords+1 {
+			proposal.ChannelID = "system-channel"
+		}
+		manyProposals = append(manyProposals, proposal)
+	}
+	chaincodeStub.GetStateByPartialCompositeKeyWithPaginationStub = paginatedProposals(t, manyProposals)
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: MaxPageSize, ChannelID: "system-channel"})
+	require.NoError(t, err)
+	require.Empty(t, result.Proposals)
+	require.Equal(t, fmt.Sprintf("proposal_request-%04d", MaxScannedRecords+1), result.Bookmark)
+
+	result, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: MaxPageSize, ChannelID: "system-channel", Bookmark: result.Bookmark})
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("request-%04d", MaxScannedRecords+1)}, proposalIDs(result))
+	require.Empty(t, result.Bookmark)
+
+	// Case: Fail to get proposals with invalid parameters
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{PageSize: -1})
+	require.EqualError(t, err, "the parameter 'PageSize' should be between 1 and 100")
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{From: "yesterday"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the parameter 'From' should be RFC3339 format")
+
+	// Case: Fail to get proposals when failed retrieving proposals
+	chaincodeStub.GetStateByPartialCompositeKeyWithPaginationReturns(nil, nil, fmt.Errorf("failed retrieving proposals"))
+	chaincodeStub.GetStateByPartialCompositeKeyWithPaginationStub = nil
+	_, err = sc.QueryProposals(transactionContext, ProposalQueryParams{})
+	require.EqualError(t, err, "error happened reading keys from ledger: failed retrieving proposals")
+}
+
+func TestQueryProposalsWithSelector(t *testing.T) {
+	chaincodeStub := &mocks.ChaincodeStub{}
+	transactionContext := &mocks.TransactionContext{}
+	transactionContext.GetStubReturns(chaincodeStub)
+	transactionContext.GetClientIdentityReturns(&mocks.ClientIdentity{})
+	sc := &SmartContract{}
+
+	expected := Proposal{
+		ObjectType: ProposalObjectType,
+		ID:         "request-1",
+		Creator:    "Org1MSP",
+		ChannelID:  "mychannel",
+		Action:     UpdateAction,
+		Status:     Committed,
+		Artifacts: Artifacts{
+			ConfigUpdate: updateBase64,
+			Signatures:   map[string]string{"Org1MSP": signatureBase64},
+		},
+		Time: "2026-01-01T01:00:00Z",
+	}
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturnsOnCall(0, &queryresult.KV{Value: expectedJSON}, nil)
+	chaincodeStub.GetQueryResultWithPaginationReturns(iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: "next"}, nil)
+
+	// Case: Get proposals for the channel committed after the given time
+	result, err := sc.QueryProposalsWithSelector(transactionContext, `{"channelID": "mychannel", "status": "committed", "time": {"$gte": "2026-01-01T00:00:00Z"}}`, 1, "")
+	require.NoError(t, err)
+	require.Equal(t, &ProposalQueryResult{Proposals: []*Proposal{&expected}, Bookmark: "next"}, result)
+	query, pageSize, _ := chaincodeStub.GetQueryResultWithPaginationArgsForCall(0)
+	require.JSONEq(t, `{"selector": {"docType": "proposal", "channelID": "mychannel", "status": "committed", "time": {"$gte": "2026-01-01T00:00:00Z"}}}`, query)
+	require.Equal(t, int32(1), pageSize)
+
+	// Case: Fail to get proposals with invalid selectors
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"action": "update"}`, 0, "")
+	require.EqualError(t, err, "the selector should contain at least one of the fields: channelID, status, time")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"artifacts": {"$gt": null}}`, 0, "")
+	require.EqualError(t, err, "the field 'artifacts' is not allowed in the selector")
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": {"$or": ["proposed"]}}`, 0, "")
+	require.EqualError(t, err, "the operator '$or' is not allowed in the selector")
+
+	// Case: Fail to get proposals when the rich query is failed
+	chaincodeStub.GetQueryResultWithPaginationReturns(nil, nil, fmt.Errorf("rich query is not supported"))
+	_, err = sc.QueryProposalsWithSelector(transactionContext, `{"status": "committed"}`, 0, "")
+	require.EqualError(t, err, "error happened executing the rich query: rich query is not supported")
+}
@@ -4,9 +4,12 @@ go 1.14
 
 require (
 	github.com/golang/protobuf v1.5.2
+	github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops v0.0.0-00010101000000-000000000000
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20220920210243-7bc6fa0dd58b
 	github.com/hyperledger/fabric-contract-api-go v1.2.0
 	github.com/hyperledger/fabric-protos-go v0.3.0
 	github.com/stretchr/testify v1.8.1
 	google.golang.org/protobuf v1.28.1
 )
+
+replace github.com/hyperledger-labs/fabric-opssc/chaincode/chaincode-ops => ../chaincode-ops
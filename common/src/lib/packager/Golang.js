@@ -61,7 +61,7 @@ class GolangPackager extends BasePackager {
 		// strictly necessary yet, they pave the way for the future where we
 		// will need to assemble sources from multiple packages
 
-		const srcDescriptors = await this.findSource(basePath, projDir);
+		const srcDescriptors = await this.findSource(basePath, projDir, isModule);
 		let descriptors = srcDescriptors.map(desc => {
 			if (isModule) {
 				desc.name = path.join('src', desc.name);
@@ -83,18 +83,21 @@ class GolangPackager extends BasePackager {
 	 * files that fit the criteria for being valid golang source (ISREG +
 	 * (*.(go|c|h|s|mod|sum))) As a convenience, we also formulate a
 	 * tar-friendly "name" for each file based on relative position to
-	 * 'basePath'.
+	 * 'basePath'. For a Go module, vendor/modules.txt is also included so that
+	 * the vendored dependencies can be used to build the chaincode.
 	 * @param basePath
 	 * @param filePath
+	 * @param {boolean} [isModule]
 	 * @returns {Promise}
 	 */
-	findSource(basePath, filePath) {
+	findSource(basePath, filePath, isModule) {
+		const vendorModulesPath = path.resolve(filePath, 'vendor', 'modules.txt');
 		return new Promise((resolve, reject) => {
 			const descriptors = [];
 			klaw(filePath)
 				.on('data', (entry) => {
 
-					if (entry.stats.isFile() && super.isSource(entry.path)) {
+					if (entry.stats.isFile() && (super.isSource(entry.path) || (isModule && path.resolve(entry.path) === vendorModulesPath))) {
 						const desc = {
 							name: path.relative(basePath, entry.path).split('\\').join('/'), // for windows style paths
 							fqp: entry.path
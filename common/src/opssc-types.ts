@@ -6,15 +6,25 @@
 
 // Types for chaincode ops
 
+export type ChaincodePackageSourceType = 'git' | 'ociImage' | 'archive';
+
 export type ChaincodePackage = {
+  sourceType?: ChaincodePackageSourceType;
   repository: string;
   commitID: string;
   pathToSourceFiles?: string;
+  imageName?: string;
+  imageDigest?: string;
+  archiveURL?: string;
+  archiveHash?: string;
   type: string;
 }
 
 export type ChaincodeDefinition = {
   sequence: number;
+  version?: string;
+  endorsementPlugin?: string;
+  validationPlugin?: string;
   initRequired: boolean;
   validationParameter: string;
   collections?: string;
@@ -29,6 +39,18 @@ export type ChaincodeUpdateProposal = {
   chaincodeDefinition: ChaincodeDefinition;
   status: string;
   time: string;
+  votingDeadline?: string;
+  notBefore?: string;
+  notAfter?: string;
+  failedTask?: string;
+  rollbackOf?: string;
+  operationTargets?: string[];
+  releaseID?: string;
+  dependsOn?: string[];
+  updatedBy?: string;
+  creatorID?: string;
+  updatedByID?: string;
+  retriedAt?: string;
 }
 
 export type ChaincodeUpdateProposalInput = {
@@ -37,11 +59,58 @@ export type ChaincodeUpdateProposalInput = {
   chaincodeName: string;
   chaincodePackage: ChaincodePackage;
   chaincodeDefinition: ChaincodeDefinition;
+  votingDeadline?: string;
+  notBefore?: string;
+  notAfter?: string;
+  channelIDs?: string[];
+  dependsOn?: string[];
 }
 
 export type ChaincodeDeploymentEventDetail = {
   proposal: ChaincodeUpdateProposal;
   operationTargets: string[];
+  notAcknowledgedOrgs?: string[];
+}
+
+export type ChaincodeExpiredEventDetail = ChaincodeUpdateProposal[];
+
+export type ChaincodeReleasedEventDetail = {
+  deployments: ChaincodeDeploymentEventDetail[];
+  expiredProposals: string[];
+  scheduledProposals?: string[];
+  rejectedProposals?: string[];
+}
+
+export type ChaincodeReleaseProposal = {
+  ID: string;
+  creator: string;
+  channelIDs: string[];
+  members: string[];
+  status: string;
+  time: string;
+}
+
+export type ChaincodeReleaseProposalInput = {
+  ID: string;
+  members: ChaincodeUpdateProposalInput[];
+}
+
+export type ChaincodeReleaseDeploymentEventDetail = {
+  release: ChaincodeReleaseProposal;
+  deployments: ChaincodeDeploymentEventDetail[];
+}
+
+export type PackageMismatchEventDetail = {
+  proposal: ChaincodeUpdateProposal;
+  packageIDs: {[mspID: string]: string};
+  divergentOrgs: string[];
+}
+
+export type ChaincodeState = {
+  channelID: string;
+  chaincodeName: string;
+  lastCommittedSequence: number;
+  inFlightProposal?: string;
 }
 
 // Types for channel ops
@@ -54,6 +123,18 @@ export interface ChannelUpdateProposal {
   action: string;
   opsProfile: any;
   artifacts: Artifacts;
+  time?: string;
+  updatedBy?: string;
+  creatorID?: string;
+  updatedByID?: string;
+}
+
+export interface ChannelAuditRecord {
+  status?: string;
+  txID: string;
+  time: string;
+  submitter?: string;
+  isDelete: boolean;
 }
 
 export interface Artifacts {
@@ -87,6 +168,18 @@ export type ChannelOpsEventDetail = {
 
 // Common types
 
+export interface OpsSCEvent<T> {
+  specVersion: string;
+  type: string;
+  source: string;
+  proposalID?: string;
+  channelID: string;
+  actor: string;
+  txID: string;
+  time: string;
+  data?: T;
+}
+
 export interface History {
   proposalID: string;
   orgID?: string;
@@ -94,16 +187,82 @@ export interface History {
   status: TaskStatus;
   data?: string;
   time?: string;
+  packageID?: string;
+  delegate?: string;
+  identityID?: string;
 }
 
 export interface TaskStatusUpdate {
   proposalID: string;
   status?: TaskStatus;
   data?: string;
+  packageID?: string;
 }
 
 export interface VoteTaskStatusUpdate extends TaskStatusUpdate{
   status?: VoteTaskStatus;
+  onBehalfOf?: string;
+}
+
+export interface VoteDelegationInput {
+  delegate: string;
+  channelIDs?: string[];
+  expiry: string;
+}
+
+export interface VoteDelegation extends VoteDelegationInput {
+  delegator: string;
+  time: string;
+}
+
+export interface AuthorizationRule {
+  function: string;
+  roles?: string[];
+  attributes?: string[];
+}
+
+export interface AuthorizationConfig {
+  mspID: string;
+  rules: AuthorizationRule[];
+  updatedByID: string;
+  time: string;
+}
+
+export interface SupersededVote {
+  vote: History;
+  action: 'changed' | 'retracted';
+  txID: string;
+  time: string;
+}
+
+export interface ArchivedChaincodeUpdateProposal {
+  proposal: ChaincodeUpdateProposal;
+  histories: History[];
+  archivedBy: string;
+  archiveTxID: string;
+  archivedAt: string;
+}
+
+export interface ArchivedChaincodeUpdateProposalSummary {
+  proposalID: string;
+  channelID: string;
+  chaincodeName: string;
+  sequence: number;
+  creator: string;
+  status: string;
+  time: string;
+  archivedAt: string;
+}
+
+export interface ChaincodeAuditRecord {
+  objectType: 'proposal' | 'history';
+  taskID?: string;
+  orgID?: string;
+  status?: string;
+  txID: string;
+  time: string;
+  submitter?: string;
+  isDelete: boolean;
 }
 
 export type TaskStatus = VoteTaskStatus | AgentTaskStatus
@@ -114,4 +273,20 @@ export interface HistoryQueryParams {
 	proposalID: string;
 	taskID?:     string;
 	orgID?:      string;
-}
\ No newline at end of file
+}
+
+export interface ProposalQueryParams {
+  pageSize: number; // the default page size is used if this is 0
+  bookmark?: string;
+  status?: string;
+  channelID?: string;
+  chaincodeName?: string; // only for chaincode update proposals
+  creator?: string;
+  from?: string; // RFC3339 (inclusive)
+  to?: string; // RFC3339 (exclusive)
+}
+
+export interface ProposalQueryResult<T> {
+  proposals: T[];
+  bookmark: string; // empty if there is no next page
+}
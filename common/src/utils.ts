@@ -8,6 +8,7 @@ import { execSync } from 'child_process';
 import fs from 'fs-extra';
 import path from 'path';
 import { logger } from './logger';
+import { OpsSCEvent } from './opssc-types';
 
 /**
  * This function execute an OS command.
@@ -76,4 +77,19 @@ export function findSingleFileOnThePath(fileOrDirPath: string): string {
     }
   }
   throw new Error(`File is not found on ${fileOrDirPath}.`);
-}
\ No newline at end of file
+}
+
+/**
+ * This function decodes the payload of a chaincode event emitted by the OpsSC chaincodes,
+ * which is wrapped in the versioned envelope.
+ *
+ * @param {Buffer | string} payload the payload of the chaincode event
+ * @return {OpsSCEvent<T>} the envelope of the event, which carries the event-specific data
+ */
+export function decodeOpsSCEvent<T>(payload: Buffer | string): OpsSCEvent<T> {
+  const event = JSON.parse(payload.toString()) as OpsSCEvent<T>;
+  if (event.specVersion?.split('.')[0] !== '1') {
+    throw new Error(`Unsupported spec version of the event: ${event.specVersion}`);
+  }
+  return event;
+}
@@ -405,7 +405,10 @@ export class ChaincodeOpsSteps extends BaseStepClass {
 
   @when(/consortium unsets max malicious orgs in votes/)
   public async unsetMaxMaliciousOrgsInVotes() {
-    const status = await this.invokeChaincodeOpsFunc('UnsetMaxMaliciousOrgsInVotes', []);
+    const proposalID = `unset-voting-config-${ChaincodeOpsSteps.SUFFIX}`;
+    let status = await this.invokeChaincodeOpsFunc('RequestGovernanceProposal', [JSON.stringify({ ID: proposalID, action: 'unset' })], 'org1');
+    expect(status).to.equals(200);
+    status = await this.invokeChaincodeOpsFunc('VoteForGovernanceProposal', [JSON.stringify({ proposalID: proposalID })], 'org2');
     expect(status).to.equals(200);
   }
 
@@ -89,8 +89,8 @@ export default class BaseStepClass {
     return `http://${org}-opssc-${service}.localho.st`;
   }
 
-  private async invokeOpsSCFunc(ccName: string, funcName: string, args: string[]): Promise<number> {
-    const response = await axios.post(`${this.getServiceEndpoint(this.environment)}/api/v1/utils/invokeTransaction`,
+  private async invokeOpsSCFunc(ccName: string, funcName: string, args: string[], org = 'org1'): Promise<number> {
+    const response = await axios.post(`${this.getServiceEndpoint(this.environment, org)}/api/v1/utils/invokeTransaction`,
       {
         channelID: BaseStepClass.OPS_CHANNEL,
         ccName: ccName,
@@ -106,8 +106,8 @@ export default class BaseStepClass {
     return response.status;
   }
 
-  protected async invokeChaincodeOpsFunc(funcName: string, args: string[]): Promise<number> {
-    return this.invokeOpsSCFunc(BaseStepClass.CC_OPS_CC_NAME, funcName, args);
+  protected async invokeChaincodeOpsFunc(funcName: string, args: string[], org = 'org1'): Promise<number> {
+    return this.invokeOpsSCFunc(BaseStepClass.CC_OPS_CC_NAME, funcName, args, org);
   }
 
   protected async invokeChannelOpsFunc(funcName: string, args: string[]): Promise<number> {
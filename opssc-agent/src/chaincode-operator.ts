@@ -10,6 +10,10 @@ import { logger } from './logger';
 import path from 'path';
 import simplegit from 'simple-git';
 import fs from 'fs-extra';
+import crypto from 'crypto';
+import http from 'http';
+import https from 'https';
+import { URL } from 'url';
 import { Notifier } from './notifier';
 import { ChaincodeLifecycleCommands, ChaincodeRequest, computePackageID, InstallRequest, PackageRequest } from 'opssc-common/chaincode-lifecycle-commands';
 import { Identity } from 'fabric-network';
@@ -28,7 +32,7 @@ export interface ChaincodeOperator {
    validate(): Promise<void>;
 
   /**
-   * Download the source code of the chaincode from the remote repository specified in the proposal.
+   * Download the source code or the package of the chaincode from the remote location specified in the proposal.
    *
    * @returns {Promise<void>}
    */
@@ -126,7 +130,8 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
       const approvalTaskStatus: TaskStatusUpdate = {
         proposalID: this.proposal.ID,
         status: 'success',
-        data: `{"packageID": "${this.packageID}"}`
+        data: `{"packageID": "${this.packageID}"}`,
+        packageID: this.packageID
       };
       return approvalTaskStatus;
     } catch (e) {
@@ -182,6 +187,9 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
   async validate(): Promise<void> {
     logger.info(`[START] Validate chaincode update proposal (proposal ID: ${this.proposal.ID})`);
     this.notifier?.notifyProgress('[START] Validate chaincode update proposal', this.proposal.ID);
+    if (!this.supportedSourceTypes().includes(this.sourceType())) {
+      throw new Error(`VALIDATION ERROR: The chaincode package source type is not supported by this operator (source type: ${this.sourceType()})`);
+    }
     try {
       const chaincodeDefinition = await this.lifecycleCommands.queryChaincodeDefinition(this.proposal.chaincodeName);
       logger.debug('chaincodeDefinition\n%s', JSON.stringify(chaincodeDefinition));
@@ -201,12 +209,16 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
   }
 
   /**
-   * Download the source code of the chaincode from the remote repository specified in the proposal.
+   * Download the source code or the package of the chaincode from the remote location specified in the proposal.
    *
    * @async
    * @returns {Promise<void>}
    */
   async download(): Promise<void> {
+    if (this.sourceType() === 'archive') {
+      await this.downloadArchive();
+      return;
+    }
     try {
       logger.info(`[START] Download chaincode (proposal ID: ${this.proposal.ID})`);
       this.notifier?.notifyProgress('[START] Download chaincode', this.proposal.ID);
@@ -260,6 +272,14 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
     logger.info(`[START] Package chaincode (proposal ID: ${this.proposal.ID})`);
     this.notifier?.notifyProgress('[START] Package chaincode', this.proposal.ID);
 
+    if (this.sourceType() === 'archive') {
+      // The prebuilt package is installed as it is
+      this.packagedChaincode = fs.readFileSync(this.archiveAbsolutePath());
+      logger.info(`[END] Package chaincode (proposal ID: ${this.proposal.ID})`);
+      this.notifier?.notifyProgress('[END] Package chaincode', this.proposal.ID);
+      return;
+    }
+
     let lang = this.proposal.chaincodePackage.type;
     switch (this.proposal.chaincodePackage.type) {
       case 'typescript':
@@ -300,7 +320,7 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
       package: this.packagedChaincode
     };
     const result = await this.lifecycleCommands.install(installRequest);
-    this.packageID = result ? result : this.packageID = computePackageID(this.chaincodeLabel(), this.packagedChaincode);
+    this.packageID = result ? result : this.packageID = computePackageID(this.packageLabel(), this.packagedChaincode);
     logger.info(`[END] Install chaincode (proposal ID: ${this.proposal.ID})`);
   }
 
@@ -317,7 +337,9 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
       chaincode: {
         name: this.proposal.chaincodeName,
         sequence: this.proposal.chaincodeDefinition.sequence,
-        version: this.proposal.chaincodeDefinition.sequence.toString(),
+        version: this.chaincodeVersion(),
+        endorsement_plugin: this.proposal.chaincodeDefinition.endorsementPlugin,
+        validation_plugin: this.proposal.chaincodeDefinition.validationPlugin,
         validation_parameter: this.decodeValidationParameterFromBase64(),
         init_required: this.proposal.chaincodeDefinition.initRequired,
         package_id: this.packageID,
@@ -344,7 +366,9 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
       chaincode: {
         name: this.proposal.chaincodeName,
         sequence: this.proposal.chaincodeDefinition.sequence,
-        version: this.proposal.chaincodeDefinition.sequence.toString(),
+        version: this.chaincodeVersion(),
+        endorsement_plugin: this.proposal.chaincodeDefinition.endorsementPlugin,
+        validation_plugin: this.proposal.chaincodeDefinition.validationPlugin,
         validation_parameter: this.decodeValidationParameterFromBase64(),
         init_required: this.proposal.chaincodeDefinition.initRequired,
         collections: this.decodeCollectionsFromBase64(),
@@ -358,6 +382,59 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
 
   // Internal methods
 
+  /**
+   * Download the prebuilt chaincode package specified in the proposal and verify it with the SHA-256 hash in the proposal.
+   *
+   * @async
+   * @returns {Promise<void>}
+   */
+  protected async downloadArchive(): Promise<void> {
+    logger.info(`[START] Download chaincode package (proposal ID: ${this.proposal.ID})`);
+    this.notifier?.notifyProgress('[START] Download chaincode package', this.proposal.ID);
+
+    const archiveURL = this.proposal.chaincodePackage.archiveURL || '';
+    const archive = await this.httpGet(archiveURL);
+    const archiveHash = crypto.createHash('sha256').update(archive).digest('hex');
+    if (archiveHash !== this.proposal.chaincodePackage.archiveHash) {
+      throw new Error(`The hash of the downloaded chaincode package is mismatched (proposed: ${this.proposal.chaincodePackage.archiveHash}, downloaded: ${archiveHash})`);
+    }
+    fs.outputFileSync(this.archiveAbsolutePath(), archive);
+
+    logger.info(`[END] Download chaincode package (proposal ID: ${this.proposal.ID})`);
+    this.notifier?.notifyProgress('[END] Download chaincode package', this.proposal.ID);
+  }
+
+  private httpGet(url: string, redirects = 5): Promise<Buffer> {
+    return new Promise((resolve, reject) => {
+      const client = url.startsWith('https:') ? https : http;
+      client.get(url, (res) => {
+        const statusCode = res.statusCode || 0;
+        if (statusCode >= 300 && statusCode < 400 && res.headers.location && redirects > 0) {
+          res.resume();
+          resolve(this.httpGet(new URL(res.headers.location, url).toString(), redirects - 1));
+          return;
+        }
+        if (statusCode !== 200) {
+          res.resume();
+          reject(new Error(`Fail to download ${url} (status code: ${statusCode})`));
+          return;
+        }
+        const chunks: Buffer[] = [];
+        res.on('data', (chunk: Buffer) => chunks.push(chunk));
+        res.on('end', () => resolve(Buffer.concat(chunks)));
+        res.on('error', reject);
+      }).on('error', reject);
+    });
+  }
+
+  protected sourceType(): string {
+    return this.proposal.chaincodePackage.sourceType || 'git';
+  }
+
+  protected supportedSourceTypes(): string[] {
+    return ['git', 'archive'];
+  }
+
   private decodeValidationParameterFromBase64(): any {
     const validationParameterAsString = Buffer.from(this.proposal.chaincodeDefinition.validationParameter.toString(), 'base64').toString();
     logger.info('Validation Parameter:\n%s', validationParameterAsString);
@@ -390,6 +467,9 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
   }
 
   protected sourceAbsolutePath(): string {
+    if (this.sourceType() !== 'git') {
+      return path.join('/tmp', 'src', this.proposal.chaincodeName);
+    }
     const basePath = this.proposal.chaincodePackage.type === 'golang' ? this.getGoPath() : '/tmp';
     // const basePath = this.getGoPath();
     return path.join(basePath, 'src', this.repositoryFolderName());
@@ -399,6 +479,10 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
     return this.proposal.chaincodePackage.repository.replace(/\.git$/g, '');
   }
 
+  protected archiveAbsolutePath(): string {
+    return path.join(this.sourceAbsolutePath(), `${this.chaincodeLabel()}.tar.gz`);
+  }
+
   protected chaincodePath(): string {
     if (!this.proposal.chaincodePackage.pathToSourceFiles || this.proposal.chaincodePackage.pathToSourceFiles === null) {
       return this.repositoryFolderName();
@@ -413,10 +497,23 @@ export class ChaincodeOperatorImpl implements ChaincodeOperator {
     return path.join(this.sourceAbsolutePath(), this.proposal.chaincodePackage.pathToSourceFiles);
   }
 
+  protected chaincodeVersion(): string {
+    return this.proposal.chaincodeDefinition.version || this.proposal.chaincodeDefinition.sequence.toString();
+  }
+
   protected chaincodeLabel(): string {
     return `${this.proposal.chaincodeName}-${this.proposal.chaincodeDefinition.sequence.toString()}`;
   }
 
+  protected packageLabel(): string {
+    if (this.sourceType() !== 'archive') {
+      return this.chaincodeLabel();
+    }
+    // The label of the prebuilt package is set by its metadata
+    const metadata = JSON.parse(execCommand(`tar -xzOf ${this.archiveAbsolutePath()} metadata.json`));
+    return metadata.label;
+  }
+
   protected remoteGitRepositoryURL(withGitCredentials: boolean): string {
     const repository = this.repositoryFolderName();
 
@@ -6,11 +6,12 @@
 
 import { logger } from './logger';
 import { OpsSCAgentCoreConfig } from './config';
-import { ChaincodeDeploymentEventDetail, ChaincodeUpdateProposal, TaskStatusUpdate } from 'opssc-common/opssc-types';
+import { ChaincodeDeploymentEventDetail, ChaincodeReleaseDeploymentEventDetail, ChaincodeReleasedEventDetail, ChaincodeUpdateProposal, TaskStatusUpdate } from 'opssc-common/opssc-types';
 import { Notifier } from './notifier';
 import { ChaincodeOperator, ChaincodeOperatorImpl } from './chaincode-operator';
 import { ContractEvent, ContractListener } from 'fabric-network';
 import { FabricClient } from 'opssc-common/fabric-client';
+import { decodeOpsSCEvent } from 'opssc-common/utils';
 import { ExternalChaincodeOperatorImpl } from './external-chaincode-operator';
 import { K8sBuilderBasedExternalChaincodeOperatorImpl } from './k8s-builder-based-external-chaincode-operator';
 
@@ -29,12 +30,16 @@ import { K8sBuilderBasedExternalChaincodeOperatorImpl } from './k8s-builder-base
  *       <li> submits the result of the above as acknowledge to the OpsSC chaincode </li>
  *     </ul>
  *   </li>
+ *   <li> When the agent receives a releasedEvent, this executes the above operations for each released proposal. </li>
+ *   <li> When the agent receives a releaseApprovedEvent, this executes the above operations for each member of the approved release. </li>
  *   <li> When the agent receives a deployEvent, this executes the following operations.
  *     <ul>
  *       <li> commits the chaincode definition based on the content of the proposal (if only selected as the executor) </li>
  *       <li> submits the result of the commit to the OpsSC chaincode </li>
  *     </ul>
  *   </li>
+ *   <li> When the agent receives a releaseDeployEvent, this executes the above operations for each member of the acknowledged release. </li>
+ *   <li> When the agent receives a rejectedEvent, withdrawnEvent or expiredEvent, this releases the dependent proposals to reject the ones waiting for the proposals. </li>
  * </ul>
  */
 export class ChaincodeOpsAgent {
@@ -73,8 +78,16 @@ export class ChaincodeOpsAgent {
         try {
           if (event.eventName.startsWith('prepareToDeployEvent')) {
             this.handlePrepareToDeployEvent(event);
+          } else if (event.eventName === 'releasedEvent') {
+            this.handleReleasedEvent(event);
+          } else if (event.eventName.startsWith('releaseApprovedEvent')) {
+            this.handleReleaseApprovedEvent(event);
           } else if (event.eventName.startsWith('deployEvent')) {
             this.handleDeployEvent(event);
+          } else if (event.eventName.startsWith('releaseDeployEvent')) {
+            this.handleReleaseDeployEvent(event);
+          } else if (['rejectedEvent', 'withdrawnEvent', 'expiredEvent'].some(name => event.eventName.startsWith(name))) {
+            this.handleNotCommittableEvent(event);
           }
         } catch (e) {
           logger.error('Got error : %s', e.toString());
@@ -87,11 +100,62 @@ export class ChaincodeOpsAgent {
    * Handle a prepareToDeployEvent.
    */
   async handlePrepareToDeployEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      const eventDetail = decodeOpsSCEvent<ChaincodeDeploymentEventDetail>(chaincodeEvent.payload).data as ChaincodeDeploymentEventDetail;
+      await this.prepareToDeploy(eventDetail);
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Handle a releasedEvent, which includes the deployments of the scheduled proposals released in the deployment windows
+   * or the waiting proposals released after their dependencies are committed.
+   */
+  async handleReleasedEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      const eventDetail = decodeOpsSCEvent<ChaincodeReleasedEventDetail>(chaincodeEvent.payload).data as ChaincodeReleasedEventDetail;
+      await Promise.all(eventDetail.deployments.map(deployment => this.prepareToDeploy(deployment)));
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Handle an event which notifies that proposals can no longer be committed (rejectedEvent, withdrawnEvent or expiredEvent),
+   * so that the proposals waiting for them are rejected.
+   */
+  async handleNotCommittableEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      await this.releaseDependentProposals();
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Handle a releaseApprovedEvent, which includes the deployments of the members of the approved release.
+   */
+  async handleReleaseApprovedEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      const eventDetail = decodeOpsSCEvent<ChaincodeReleaseDeploymentEventDetail>(chaincodeEvent.payload).data as ChaincodeReleaseDeploymentEventDetail;
+      await Promise.all(eventDetail.deployments.map(deployment => this.prepareToDeploy(deployment)));
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Prepare to deploy the chaincode based on the detail of a prepareToDeployEvent.
+   */
+  private async prepareToDeploy(eventDetail: ChaincodeDeploymentEventDetail) {
     let proposalID = '';
     let skipped = false;
     try {
-      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
-      const eventDetail = JSON.parse(chaincodeEvent.payload) as ChaincodeDeploymentEventDetail;
       logger.info('Prepare to deploy event: \n%s', JSON.stringify(eventDetail));
       proposalID = eventDetail.proposal.ID;
       this.notifier?.notifyEvent('prepareToDeployEvent',
@@ -123,11 +187,35 @@ export class ChaincodeOpsAgent {
    * Handle a deployEvent.
    */
   async handleDeployEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      const eventDetail = decodeOpsSCEvent<ChaincodeDeploymentEventDetail>(chaincodeEvent.payload).data as ChaincodeDeploymentEventDetail;
+      await this.deploy(eventDetail);
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Handle a releaseDeployEvent, which includes the deployments of the members of the acknowledged release.
+   */
+  async handleReleaseDeployEvent(chaincodeEvent: { [key: string]: any }) {
+    try {
+      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
+      const eventDetail = decodeOpsSCEvent<ChaincodeReleaseDeploymentEventDetail>(chaincodeEvent.payload).data as ChaincodeReleaseDeploymentEventDetail;
+      await Promise.all(eventDetail.deployments.map(deployment => this.deploy(deployment)));
+    } catch (e) {
+      logger.error('Got error : %s', e.toString());
+    }
+  }
+
+  /*
+   * Deploy the chaincode based on the detail of a deployEvent.
+   */
+  private async deploy(eventDetail: ChaincodeDeploymentEventDetail) {
     let proposalID = '';
     let skipped = false;
     try {
-      logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
-      const eventDetail = JSON.parse(chaincodeEvent.payload) as ChaincodeDeploymentEventDetail;
       logger.info('Deploy event: \n%s', JSON.stringify(eventDetail));
       proposalID = eventDetail.proposal.ID;
       this.notifier?.notifyEvent('deployEvent',
@@ -185,6 +273,21 @@ export class ChaincodeOpsAgent {
     };
     await this.fabricClient.submitTransaction(request);
     logger.info(`[END] Register results on commit (proposalID ${taskStatusUpdate.proposalID})`);
+
+    await this.releaseDependentProposals();
+  }
+
+  /*
+   * Invoke an transaction to the OpsSC chaincode to release the proposals waiting for the committed proposals.
+   */
+  private async releaseDependentProposals() {
+    const request = {
+      channelID: this.config.opssc.channelID,
+      chaincodeName: this.config.opssc.chaincodes.chaincodeOpsCCName,
+      func: 'ReleaseDependentProposals',
+      args: []
+    };
+    await this.fabricClient.submitTransaction(request);
   }
 
   /*
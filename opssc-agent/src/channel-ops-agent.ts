@@ -10,6 +10,7 @@ import { Notifier } from './notifier';
 import { ChannelOperator, ChannelOperatorImpl } from './channel-operator';
 import { ContractEvent, ContractListener } from 'fabric-network';
 import { FabricClient } from 'opssc-common/fabric-client';
+import { decodeOpsSCEvent } from 'opssc-common/utils';
 import { OpsSCAgentCoreConfig } from './config';
 import { BootstrapOperatorImpl } from './bootstrap-operator';
 
@@ -72,7 +73,7 @@ export class ChannelOpsAgent {
   private async handleReadyToUpdateConfigEvent(chaincodeEvent: { [key: string]: any }) {
     try {
       logger.debug('Chaincode event: \n%s', JSON.stringify(chaincodeEvent));
-      const eventDetail = JSON.parse(chaincodeEvent.payload) as ChannelOpsEventDetail;
+      const eventDetail = decodeOpsSCEvent<ChannelOpsEventDetail>(chaincodeEvent.payload).data as ChannelOpsEventDetail;
       logger.info('Deploy event: \n%s', JSON.stringify(eventDetail));
       const proposalID = eventDetail.proposalID;
       this.notifier?.notifyEvent('readyToUpdateConfigEvent',
@@ -114,6 +115,15 @@ export class ChannelOpsAgent {
       args: [proposalID]
     };
     await this.fabricClient.submitTransaction(request);
+
+    // Release the chaincode update proposals waiting for the channel update
+    const releaseRequest = {
+      channelID: this.config.opssc.channelID,
+      chaincodeName: this.config.opssc.chaincodes.chaincodeOpsCCName,
+      func: 'ReleaseDependentProposals',
+      args: []
+    };
+    await this.fabricClient.submitTransaction(releaseRequest);
   }
 
   /*
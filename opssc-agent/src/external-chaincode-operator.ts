@@ -12,6 +12,11 @@ import { execCommand } from 'opssc-common/utils';
 export class ExternalChaincodeOperatorImpl extends ChaincodeOperatorImpl {
 
   async package() {
+    if (this.sourceType() === 'archive') {
+      // The prebuilt package already includes the connection information of the chaincode server
+      return super.package();
+    }
+
     logger.info(`[START] Package external chaincode (proposal ID: ${this.proposal.ID})`);
     this.notifier?.notifyProgress('[START] Package chaincode', this.proposal.ID);
 
@@ -54,21 +59,18 @@ export class ExternalChaincodeOperatorImpl extends ChaincodeOperatorImpl {
     await super.install();
 
     // Next step: Build and launch external chaincode server
-    if (this.config.ccs.launchFromAgent) {
+    // (The chaincode server for the prebuilt package should be launched by the organization itself)
+    if (this.config.ccs.launchFromAgent && this.sourceType() !== 'archive') {
       logger.info(`[START] Build and launch external chaincode server (proposal ID: ${this.proposal.ID})`);
       this.notifier?.notifyProgress('[START] Build and launch external chaincode server', this.proposal.ID);
 
       const chaincodeName = this.proposal.chaincodeName;
-      const imageTag = this.proposal.chaincodeDefinition.sequence.toString();
-      const remote = this.remoteGitRepositoryURL(true);
-      const pathToSourceFiles = this.proposal.chaincodePackage.pathToSourceFiles ? `/${this.proposal.chaincodePackage.pathToSourceFiles}` : '';
 
       // Workaround: Avoid 'UPGRADE FAILED: another operation (install/upgrade/rollback) is in progress'
       // execCommand(`helm -n ${this.config.ccs.namespace} uninstall ${this.config.ccs.servicePrefix}-${chaincodeName}-${this.config.ccs.serviceSuffix} || true`);
 
-      // Build chaincode server image
-      const setOptionForImageNameOverride = (this.config.ccs.pullRegistry !== '') ? `--set pullImageNameOverride=${this.config.ccs.pullRegistry}/${chaincodeName}` : '';
-      const helmCommand = `helm -n ${this.config.ccs.namespace} upgrade ${this.config.ccs.servicePrefix}-${chaincodeName}-${this.config.ccs.serviceSuffix} --set name=${this.config.ccs.servicePrefix}-${chaincodeName}-${this.config.ccs.serviceSuffix} --set imageName=${this.config.ccs.registry}/${chaincodeName} ${setOptionForImageNameOverride} --set imageTag=${imageTag} --set git.repositoryURL=${remote} --set git.commitID=${this.proposal.chaincodePackage.commitID} --set git.pathToSourceFiles=${pathToSourceFiles} --set chaincode.ccID=${this.packageID} --set imagePullSecretName=${this.config.ccs.ccServerImagePullSecretName} --set serviceAccountName=${this.config.ccs.serviceAccountName} /opt/chart --install --timeout ${this.config.ccs.helmTimeout} --wait`;
+      // Build chaincode server image (or use the proposed image as it is)
+      const helmCommand = `helm -n ${this.config.ccs.namespace} upgrade ${this.config.ccs.servicePrefix}-${chaincodeName}-${this.config.ccs.serviceSuffix} --set name=${this.config.ccs.servicePrefix}-${chaincodeName}-${this.config.ccs.serviceSuffix} ${this.helmImageOptions()} --set chaincode.ccID=${this.packageID} --set imagePullSecretName=${this.config.ccs.ccServerImagePullSecretName} --set serviceAccountName=${this.config.ccs.serviceAccountName} /opt/chart --install --timeout ${this.config.ccs.helmTimeout} --wait`;
       logger.info(`Helm command: ${helmCommand}`);
       execCommand(helmCommand);
 
@@ -78,6 +80,29 @@ export class ExternalChaincodeOperatorImpl extends ChaincodeOperatorImpl {
   }
 
   async download() {
+    if (this.sourceType() === 'archive') {
+      return super.download();
+    }
     // The download process is skipped because the source code of the chaincode is cloned when building the images in the other step.
+    // (The proposed image is also pulled when launching the chaincode server.)
+  }
+
+  protected supportedSourceTypes(): string[] {
+    return ['git', 'ociImage', 'archive'];
+  }
+
+  private helmImageOptions(): string {
+    if (!this.config.ccs) throw new Error('ChaincodeServerConfig should be set');
+
+    if (this.sourceType() === 'ociImage') {
+      return `--set buildImage=false --set imageName=${this.proposal.chaincodePackage.imageName} --set imageDigest=${this.proposal.chaincodePackage.imageDigest}`;
+    }
+
+    const chaincodeName = this.proposal.chaincodeName;
+    const imageTag = this.proposal.chaincodeDefinition.sequence.toString();
+    const remote = this.remoteGitRepositoryURL(true);
+    const pathToSourceFiles = this.proposal.chaincodePackage.pathToSourceFiles ? `/${this.proposal.chaincodePackage.pathToSourceFiles}` : '';
+    const setOptionForImageNameOverride = (this.config.ccs.pullRegistry !== '') ? `--set pullImageNameOverride=${this.config.ccs.pullRegistry}/${chaincodeName}` : '';
+    return `--set imageName=${this.config.ccs.registry}/${chaincodeName} ${setOptionForImageNameOverride} --set imageTag=${imageTag} --set git.repositoryURL=${remote} --set git.commitID=${this.proposal.chaincodePackage.commitID} --set git.pathToSourceFiles=${pathToSourceFiles}`;
   }
 }
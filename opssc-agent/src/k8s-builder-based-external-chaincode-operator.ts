@@ -12,8 +12,13 @@ import { execCommand } from 'opssc-common/utils';
 export class K8sBuilderBasedExternalChaincodeOperatorImpl extends ChaincodeOperatorImpl {
 
   async package() {
-    // Build chaincode server image
-    const digest = this.buildChaincodeServerImage();
+    if (this.sourceType() === 'archive') {
+      // The prebuilt package already includes the image information of the chaincode server
+      return super.package();
+    }
+
+    // Build chaincode server image (or use the proposed image as it is)
+    const digest = this.sourceType() === 'ociImage' ? this.proposal.chaincodePackage.imageDigest : this.buildChaincodeServerImage();
 
     // Packaging chaincode
     logger.info(`[START] Package external chaincode (proposal ID: ${this.proposal.ID})`);
@@ -27,8 +32,9 @@ export class K8sBuilderBasedExternalChaincodeOperatorImpl extends ChaincodeOpera
     if (!this.config.ccs) throw new Error('ChaincodeServerConfig should be set');
 
     const registryName = (this.config.ccs.pullRegistry !== '') ? this.config.ccs.pullRegistry : this.config.ccs.registry;
+    const imageName = this.sourceType() === 'ociImage' ? this.proposal.chaincodePackage.imageName : `${registryName}/${this.proposal.chaincodeName}`;
     const imageJSON = {
-      'name': `${registryName}/${this.proposal.chaincodeName}`,
+      'name': imageName,
       'digest': digest
     };
     fs.outputJSONSync(imageJSONFile, imageJSON);
@@ -76,6 +82,14 @@ export class K8sBuilderBasedExternalChaincodeOperatorImpl extends ChaincodeOpera
   }
 
   async download() {
+    if (this.sourceType() === 'archive') {
+      return super.download();
+    }
     // The download process is skipped because the source code of the chaincode is cloned when building the images in the other step.
+    // (The proposed image is also pulled by the k8s builder.)
+  }
+
+  protected supportedSourceTypes(): string[] {
+    return ['git', 'ociImage', 'archive'];
   }
 }
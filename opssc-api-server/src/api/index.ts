@@ -8,7 +8,7 @@ import express, { NextFunction, Request, Response, Router } from 'express';
 import { ChaincodeLifecycleCommands } from 'opssc-common/chaincode-lifecycle-commands';
 import { ChannelCommands } from 'opssc-common/channel-commands';
 import { FabricClient } from 'opssc-common/fabric-client';
-import { ChaincodeUpdateProposalInput, ChannelUpdateProposalInput, HistoryQueryParams, VoteTaskStatusUpdate } from 'opssc-common/opssc-types';
+import { AuthorizationRule, ChaincodeUpdateProposalInput, ChannelUpdateProposalInput, HistoryQueryParams, ProposalQueryParams, VoteDelegationInput, VoteTaskStatusUpdate } from 'opssc-common/opssc-types';
 import { logger } from '../logger';
 import { OpsSCAPIServerConfig } from '../config';
 
@@ -79,6 +79,20 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     return await fabricClient.submitTransaction(request);
   }
 
+  function proposalQueryParams(req: Request): ProposalQueryParams {
+    const optional = (name: string) => req.query[name] !== undefined ? String(req.query[name]) : undefined;
+    return {
+      pageSize: req.query.pageSize !== undefined ? Number(req.query.pageSize) : 0,
+      bookmark: optional('bookmark'),
+      status: optional('status'),
+      channelID: optional('channelID'),
+      chaincodeName: optional('chaincodeName'),
+      creator: optional('creator'),
+      from: optional('from'),
+      to: optional('to')
+    };
+  }
+
   // ----- REST API to interact the OpsSC chaincode for operating chaincodes and to query information on chaincodes
 
   router.get('/chaincode/getInstalledChaincodes', async (req, res) => {
@@ -159,6 +173,17 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     }
   });
 
+  router.get('/chaincode/queryProposals', async (req, res) => {
+    try {
+      const result = JSON.parse(await queryChaincodeOpsSC('QueryProposals', JSON.stringify(proposalQueryParams(req))));
+      res.json(result);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
   router.get('/chaincode/proposals/:id', async (req, res) => {
     try {
       const proposalID = req.params.id;
@@ -189,11 +214,24 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     }
   });
 
+  router.get('/chaincode/proposals/:id/auditTrail', async (req, res) => {
+    try {
+      const proposalID = req.params.id;
+      const records = JSON.parse(await queryChaincodeOpsSC('GetProposalAuditTrail', proposalID));
+      res.json(records);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
   router.post('/chaincode/proposals/:id', async (req, res) => {
     try {
       const input = req.body.proposal as ChaincodeUpdateProposalInput;
       input.ID = req.params.id;
-      const result = JSON.parse(await invokeChaincodeOpsSC('RequestProposal', JSON.stringify(input)));
+      const func = input.channelIDs ? 'RequestMultiChannelProposal' : 'RequestProposal';
+      const result = JSON.parse(await invokeChaincodeOpsSC(func, JSON.stringify(input)));
       res.json(result);
     } catch (e) {
       res.status(500).json({
@@ -233,6 +271,118 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     }
   });
 
+  router.post('/chaincode/archiveProposals', async (req, res) => {
+    try {
+      const before = String(req.body.before);
+      const statuses = req.body.statuses !== undefined ? req.body.statuses as string[] : [];
+      const result = JSON.parse(await invokeChaincodeOpsSC('ArchiveProposals', before, JSON.stringify(statuses)));
+      res.json(result);
+    } catch (e) {
+      logger.error(e.message);
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/chaincode/archivedProposals', async (req, res) => {
+    try {
+      const channelID = String(req.query.channelID);
+      const chaincodeName = req.query.chaincodeName !== undefined ? String(req.query.chaincodeName) : '';
+      const summaries = JSON.parse(await queryChaincodeOpsSC('GetArchivedProposalSummaries', channelID, chaincodeName));
+      res.json(summaries);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/chaincode/archivedProposals/:id', async (req, res) => {
+    try {
+      const proposalID = req.params.id;
+      const archived = JSON.parse(await queryChaincodeOpsSC('GetArchivedProposal', proposalID));
+      res.json(archived);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/chaincode/voteDelegations', async (req, res) => {
+    try {
+      const delegations = JSON.parse(await queryChaincodeOpsSC('GetAllVoteDelegations'));
+      res.json(delegations);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/chaincode/voteDelegations/:delegator', async (req, res) => {
+    try {
+      const delegator = req.params.delegator;
+      const delegation = JSON.parse(await queryChaincodeOpsSC('GetVoteDelegation', delegator));
+      res.json(delegation);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.post('/chaincode/voteDelegation', async (req, res) => {
+    try {
+      const input = req.body.delegation as VoteDelegationInput;
+      const result = JSON.parse(await invokeChaincodeOpsSC('DelegateVotes', JSON.stringify(input)));
+      res.json(result);
+    } catch (e) {
+      logger.error(e.message);
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.post('/chaincode/voteDelegation/revoke', async (req, res) => {
+    try {
+      const result = await invokeChaincodeOpsSC('RevokeVoteDelegation');
+      res.json(result);
+    } catch (e) {
+      logger.error(e.message);
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/chaincode/authorizationConfig/:mspID', async (req, res) => {
+    try {
+      const mspID = req.params.mspID;
+      const config = await queryChaincodeOpsSC('GetAuthorizationConfig', mspID);
+      res.json(config ? JSON.parse(config) : null);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.post('/chaincode/authorizationRules', async (req, res) => {
+    try {
+      const rules = (req.body.rules || []) as AuthorizationRule[];
+      const result = await invokeChaincodeOpsSC('SetAuthorizationRules', JSON.stringify(rules));
+      res.json(result ? JSON.parse(result) : null);
+    } catch (e) {
+      logger.error(e.message);
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
   // ----- REST API to interact the OpsSC chaincode for operating channels and to query information on channels
 
   const verifyChannelProposalAPIEnabled = async (_: Request, res: Response, next: NextFunction) => {
@@ -371,6 +521,44 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     }
   });
 
+  router.get('/channel/proposals/:id/auditTrail', verifyChannelProposalAPIEnabled, async (req, res) => {
+    try {
+      const proposalID = req.params.id;
+      const records = JSON.parse(await queryChannelOpsSC('GetProposalAuditTrail', proposalID));
+
+      res.json(records);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.get('/channel/authorizationConfig/:mspID', verifyChannelProposalAPIEnabled, async (req, res) => {
+    try {
+      const mspID = req.params.mspID;
+      const config = await queryChannelOpsSC('GetAuthorizationConfig', mspID);
+      res.json(config ? JSON.parse(config) : null);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
+  router.post('/channel/authorizationRules', verifyChannelProposalAPIEnabled, async (req, res) => {
+    try {
+      const rules = (req.body.rules || []) as AuthorizationRule[];
+      const result = await invokeChannelOpsSC('SetAuthorizationRules', JSON.stringify(rules));
+      res.json(result ? JSON.parse(result) : null);
+    } catch (e) {
+      logger.error(e.message);
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
   router.get('/channel/proposals', verifyChannelProposalAPIEnabled, async (req, res) => {
     try {
       const proposals = JSON.parse(await queryChannelOpsSC('GetAllProposals'));
@@ -383,6 +571,18 @@ export default function router(fabricClient: FabricClient, opsSCAPIServerConfig:
     }
   });
 
+  router.get('/channel/queryProposals', verifyChannelProposalAPIEnabled, async (req, res) => {
+    try {
+      const params: ProposalQueryParams = { ...proposalQueryParams(req), chaincodeName: undefined };
+      const result = JSON.parse(await queryChannelOpsSC('QueryProposals', JSON.stringify(params)));
+      res.json(result);
+    } catch (e) {
+      res.status(500).json({
+        message: e.toString()
+      });
+    }
+  });
+
   router.get('/channel/systemConfigBlock', async (req, res) => {
     let channelCommands;
     try {